@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Skarlso/dependabot-bundler/pkg"
+	"github.com/Skarlso/dependabot-bundler/pkg/config"
+)
+
+// parseBots parses the values of --bots. A bot is its login, optionally followed by = and the name of its
+// parser, for example app/dependabot or internal-bot=renovate.
+func parseBots(values []string) ([]pkg.Bot, error) {
+	bots := make([]pkg.Bot, 0, len(values))
+
+	for _, value := range values {
+		name, parser, _ := strings.Cut(strings.TrimSpace(value), "=")
+		bot := pkg.Bot{Name: name, Parser: parser}
+
+		if err := bot.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid value for --bots %q: %w", value, err)
+		}
+
+		bots = append(bots, bot)
+	}
+
+	return bots, nil
+}
+
+// resolveBots returns the bots of the --bots flag. If there are none, the bots of the config file are used.
+// Without bots, the login of --bot-name is used.
+func resolveBots(rootArgs *rootArgsStruct, file *config.File) ([]pkg.Bot, error) {
+	bots, err := parseBots(rootArgs.bots)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bots) == 0 && file != nil {
+		bots = file.Bots
+	}
+
+	return bots, nil
+}
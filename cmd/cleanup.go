@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Skarlso/dependabot-bundler/pkg"
+	"github.com/spf13/cobra"
+)
+
+type cleanupArgsStruct struct {
+	maxAge time.Duration
+	dryRun bool
+}
+
+func createCleanupCommand(rootArgs *rootArgsStruct) *cobra.Command {
+	cleanupArgs := &cleanupArgsStruct{}
+
+	cleanupCmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Delete stale branches and PRs created by the bundler",
+	}
+
+	flag := cleanupCmd.Flags()
+	flag.DurationVar(
+		&cleanupArgs.maxAge,
+		"max-age",
+		0,
+		"--max-age delete branches without a PR and close PRs not updated for longer than this, e.g. 720h",
+	)
+	flag.BoolVar(
+		&cleanupArgs.dryRun,
+		"dry-run",
+		false,
+		"--dry-run only print what would be deleted",
+	)
+
+	cleanupCmd.RunE = cleanupRunE(rootArgs, cleanupArgs)
+
+	return cleanupCmd
+}
+
+func cleanupRunE(rootArgs *rootArgsStruct, cleanupArgs *cleanupArgsStruct) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if rootArgs.forge != forgeGitHub {
+			return fmt.Errorf("cleanup is only supported on GitHub")
+		}
+
+		// the groups can have branch prefixes of their own
+		file, dependabot, err := loadConfigs(cmd.Flags(), rootArgs)
+		if err != nil {
+			return err
+		}
+
+		log := newLogger(rootArgs)
+
+		groups, _, err := resolveGroups(rootArgs, file, dependabot, log)
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient(rootArgs)
+		if err != nil {
+			return err
+		}
+
+		bundler := pkg.NewBundler(pkg.Config{
+			Owner:  rootArgs.owner,
+			Repo:   rootArgs.repo,
+			Groups: groups,
+			Git:    client.Git,
+			Pulls:  client.PullRequests,
+			Logger: log,
+		})
+
+		if err := bundler.Cleanup(pkg.CleanupOptions{
+			MaxAge: cleanupArgs.maxAge,
+			DryRun: cleanupArgs.dryRun,
+		}); err != nil {
+			return fmt.Errorf("failed to clean up: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/spf13/pflag"
+
+	"github.com/Skarlso/dependabot-bundler/pkg"
+	"github.com/Skarlso/dependabot-bundler/pkg/config"
+	"github.com/Skarlso/dependabot-bundler/pkg/logger"
+)
+
+// loadConfigs reads the config file of the bundler and, if it's enabled, the Dependabot configuration, and fills
+// the arguments which weren't set on the command line with their values. The config file of the bundler takes
+// precedence.
+func loadConfigs(flags *pflag.FlagSet, rootArgs *rootArgsStruct) (*config.File, *config.Dependabot, error) {
+	file, err := loadConfigFile(flags, rootArgs.configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if file != nil {
+		applyConfigFile(flags, rootArgs, file)
+	}
+
+	if !rootArgs.useDependabot {
+		return file, nil, nil
+	}
+
+	dependabot, err := config.LoadDependabot(rootArgs.dependabotPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load dependabot config: %w", err)
+	}
+
+	if dependabot.TargetBranch() != "" && !flags.Changed("target-branch") && (file == nil || file.TargetBranch == "") {
+		rootArgs.targetBranch = dependabot.TargetBranch()
+	}
+
+	return file, dependabot, nil
+}
+
+// resolveGroups returns the groups of the --group flags. If there are none, the groups of the config file are
+// used, then the groups of the Dependabot configuration. The groups of the Dependabot configuration only cover
+// some of the updates, so the updates of no group are bundled as well, which is reported by the second result.
+func resolveGroups(
+	rootArgs *rootArgsStruct,
+	file *config.File,
+	dependabot *config.Dependabot,
+	log logger.Logger,
+) ([]pkg.Group, bool, error) {
+	groups, err := parseGroups(rootArgs.groups)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(groups) == 0 && file != nil {
+		groups = file.Groups
+	}
+
+	if len(groups) == 0 && dependabot != nil {
+		groups = dependabot.Groups(log)
+
+		return groups, len(groups) > 0, nil
+	}
+
+	return groups, false, nil
+}
+
+// loadConfigFile reads the config file. A missing file is only an error if its path was set explicitly.
+func loadConfigFile(flags *pflag.FlagSet, path string) (*config.File, error) {
+	file, err := config.Load(path)
+	if errors.Is(err, fs.ErrNotExist) && !flags.Changed("config") {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config file: %w", err)
+	}
+
+	return file, nil
+}
+
+// applyConfigFile fills the arguments which weren't set on the command line with the values of the config file.
+func applyConfigFile(flags *pflag.FlagSet, rootArgs *rootArgsStruct, file *config.File) {
+	// flags which were set on the command line or by their environment variable take precedence, also if they
+	// are set to their default value
+	overridden := flags.Changed
+	str := func(name string, target *string, value string) {
+		if value != "" && !overridden(name) {
+			*target = value
+		}
+	}
+	list := func(name string, target *[]string, value []string) {
+		if len(value) > 0 && !overridden(name) {
+			*target = value
+		}
+	}
+	boolean := func(name string, target *bool, value *bool) {
+		if value != nil && !overridden(name) {
+			*target = *value
+		}
+	}
+	integer := func(name string, target *int, value int) {
+		if value != 0 && !overridden(name) {
+			*target = value
+		}
+	}
+
+	str("target-branch", &rootArgs.targetBranch, file.TargetBranch)
+	list("labels", &rootArgs.labels, file.Labels)
+	str("bot-name", &rootArgs.botName, file.BotName)
+	str("author-name", &rootArgs.authorName, file.Author.Name)
+	str("author-email", &rootArgs.authorEmail, file.Author.Email)
+	str("pr-title", &rootArgs.prTitle, file.PRTitle)
+	str("close-superseded", &rootArgs.closeMode, file.CloseSuperseded)
+	boolean("comment-on-prs", &rootArgs.commentOnPRs, file.CommentOnPRs)
+	boolean("verify-bots", &rootArgs.verifyBots, file.VerifyBots)
+	boolean("require-verified-commits", &rootArgs.verifiedOnly, file.RequireVerifiedCommits)
+	boolean("commit-per-dependency", &rootArgs.perDep, file.CommitPerDependency)
+	integer("blob-threshold", &rootArgs.blobSize, file.BlobThreshold)
+	str("backend", &rootArgs.backend, file.Backend)
+	boolean("keep-on-failure", &rootArgs.keepOnFail, file.KeepOnFailure)
+	str("pr-title-template", &rootArgs.templates.prTitle, file.Templates.PRTitle)
+	str("pr-body-template", &rootArgs.templates.prBody, file.Templates.PRBody)
+	str("commit-message-template", &rootArgs.templates.commitMessage, file.Templates.CommitMessage)
+	list("include-ecosystems", &rootArgs.filter.IncludeEcosystems, file.Filter.IncludeEcosystems)
+	list("exclude-ecosystems", &rootArgs.filter.ExcludeEcosystems, file.Filter.ExcludeEcosystems)
+	list("include-directories", &rootArgs.filter.IncludeDirectories, file.Filter.IncludeDirectories)
+	list("exclude-directories", &rootArgs.filter.ExcludeDirectories, file.Filter.ExcludeDirectories)
+	list("include-dependencies", &rootArgs.filter.IncludeDependencies, file.Filter.IncludeDependencies)
+	list("exclude-dependencies", &rootArgs.filter.ExcludeDependencies, file.Filter.ExcludeDependencies)
+	list("include-labels", &rootArgs.filter.IncludeLabels, file.Filter.IncludeLabels)
+	list("exclude-labels", &rootArgs.filter.ExcludeLabels, file.Filter.ExcludeLabels)
+	str("max-update-level", &rootArgs.maxLevel, file.MaxUpdateLevel)
+	boolean("exclude-major", &rootArgs.excludeMajor, file.ExcludeMajor)
+	str("signing-name", &rootArgs.pgp.name, file.Signing.Name)
+	str("signing-email", &rootArgs.pgp.email, file.Signing.Email)
+	integer("signing-key-bit-length", &rootArgs.pgp.bitLength, file.Signing.BitLength)
+	str("git-signing-key", &rootArgs.gitSignKey, file.Signing.GitKey)
+	boolean("use-dependabot-config", &rootArgs.useDependabot, file.UseDependabotConfig)
+}
+
+// declarations returns the ecosystem and directory pairs of the Dependabot configuration, if there is one.
+func declarations(dependabot *config.Dependabot) []pkg.Declaration {
+	if dependabot == nil {
+		return nil
+	}
+
+	return dependabot.Declarations()
+}
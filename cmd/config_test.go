@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/config"
+)
+
+// testFlags returns some of the flags of the root command, bound to rootArgs.
+func testFlags(rootArgs *rootArgsStruct) *pflag.FlagSet {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringVar(&rootArgs.token, "token", "", "")
+	flags.StringVar(&rootArgs.owner, "owner", "", "")
+	flags.StringVar(&rootArgs.repo, "repo", "", "")
+	flags.StringVar(&rootArgs.targetBranch, "target-branch", "main", "")
+	flags.StringSliceVar(&rootArgs.labels, "labels", nil, "")
+	flags.BoolVar(&rootArgs.verifyBots, "verify-bots", true, "")
+	flags.StringArrayVar(&rootArgs.groups, "group", nil, "")
+	flags.StringVar(&rootArgs.configPath, "config", config.DefaultPath, "")
+	flags.BoolVar(&rootArgs.useDependabot, "use-dependabot-config", false, "")
+	flags.StringVar(&rootArgs.dependabotPath, "dependabot-config", config.DefaultDependabotPath, "")
+
+	return flags
+}
+
+func TestApplyConfigFile(t *testing.T) {
+	verifyBots := false
+	file := &config.File{
+		TargetBranch: "develop",
+		Labels:       []string{"dependencies"},
+		VerifyBots:   &verifyBots,
+	}
+
+	tests := []struct {
+		name         string
+		args         []string
+		targetBranch string
+		labels       []string
+		verifyBots   bool
+	}{
+		{
+			name:         "the file overrides the defaults",
+			targetBranch: "develop",
+			labels:       []string{"dependencies"},
+			verifyBots:   false,
+		},
+		{
+			name:         "flags override the file",
+			args:         []string{"--target-branch", "release", "--labels", "bundle"},
+			targetBranch: "release",
+			labels:       []string{"bundle"},
+			verifyBots:   false,
+		},
+		{
+			name:         "flags set to their default override the file",
+			args:         []string{"--target-branch", "main", "--verify-bots=true"},
+			targetBranch: "main",
+			labels:       []string{"dependencies"},
+			verifyBots:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootArgs := &rootArgsStruct{}
+			flags := testFlags(rootArgs)
+			require.NoError(t, flags.Parse(tt.args))
+
+			applyConfigFile(flags, rootArgs, file)
+
+			assert.Equal(t, tt.targetBranch, rootArgs.targetBranch)
+			assert.Equal(t, tt.labels, rootArgs.labels)
+			assert.Equal(t, tt.verifyBots, rootArgs.verifyBots)
+		})
+	}
+}
+
+func TestLoadConfigsDependabot(t *testing.T) {
+	dir := t.TempDir()
+	dependabotPath := filepath.Join(dir, "dependabot.yml")
+	require.NoError(t, os.WriteFile(dependabotPath, []byte(`version: 2
+updates:
+  - package-ecosystem: gomod
+    directory: /
+    target-branch: develop
+`), 0o600))
+	configPath := filepath.Join(dir, "bundler.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte("version: 1\nuse-dependabot-config: true\n"), 0o600))
+	emptyPath := filepath.Join(dir, "empty.yml")
+	require.NoError(t, os.WriteFile(emptyPath, []byte("version: 1\n"), 0o600))
+
+	tests := []struct {
+		name         string
+		args         []string
+		enabled      bool
+		targetBranch string
+	}{
+		{
+			name:         "the Dependabot configuration is ignored by default",
+			args:         []string{"--config", emptyPath},
+			targetBranch: "main",
+		},
+		{
+			name:         "the flag enables it",
+			args:         []string{"--config", emptyPath, "--use-dependabot-config"},
+			enabled:      true,
+			targetBranch: "develop",
+		},
+		{
+			name:         "the config file enables it",
+			args:         []string{"--config", configPath},
+			enabled:      true,
+			targetBranch: "develop",
+		},
+		{
+			name:         "flags override its target branch",
+			args:         []string{"--config", configPath, "--target-branch", "main"},
+			enabled:      true,
+			targetBranch: "main",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootArgs := &rootArgsStruct{}
+			flags := testFlags(rootArgs)
+			require.NoError(t, flags.Parse(append(tt.args, "--dependabot-config", dependabotPath)))
+
+			_, dependabot, err := loadConfigs(flags, rootArgs)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.enabled, dependabot != nil)
+			assert.Equal(t, tt.targetBranch, rootArgs.targetBranch)
+		})
+	}
+}
+
+func TestLoadConfigsMissingDependabot(t *testing.T) {
+	rootArgs := &rootArgsStruct{}
+	flags := testFlags(rootArgs)
+	require.NoError(t, flags.Parse([]string{
+		"--use-dependabot-config",
+		"--dependabot-config", filepath.Join(t.TempDir(), "dependabot.yml"),
+	}))
+
+	_, _, err := loadConfigs(flags, rootArgs)
+	assert.ErrorContains(t, err, "failed to load dependabot config")
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// envPrefix is the prefix of the environment variables of the flags.
+const envPrefix = "BUNDLER_"
+
+// envName returns the environment variable of a flag, for example BUNDLER_TARGET_BRANCH for --target-branch.
+func envName(flag string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// applyEnv sets the flags which weren't set on the command line from their environment variables. Empty
+// variables are ignored. Flags which can be repeated take one value per line. If they are still unset,
+// the token, owner and repo are taken from GITHUB_TOKEN and GITHUB_REPOSITORY, which GitHub Actions provides.
+func applyEnv(flags *pflag.FlagSet) error {
+	var err error
+
+	flags.VisitAll(func(flag *pflag.Flag) {
+		value := os.Getenv(envName(flag.Name))
+		if err != nil || flag.Changed || value == "" {
+			return
+		}
+
+		values := []string{value}
+		if flag.Value.Type() == "stringArray" {
+			values = strings.Split(strings.TrimSpace(value), "\n")
+		}
+
+		for _, v := range values {
+			if setErr := flags.Set(flag.Name, strings.TrimSpace(v)); setErr != nil {
+				err = fmt.Errorf("invalid value for %s: %w", envName(flag.Name), setErr)
+
+				return
+			}
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return applyGitHubEnv(flags)
+}
+
+// applyGitHubEnv sets the token, owner and repo from the variables of GitHub Actions if they are still empty.
+// The value is checked instead of whether the flag was set, so an empty input of the action doesn't hide them.
+func applyGitHubEnv(flags *pflag.FlagSet) error {
+	if err := setIfEmpty(flags, "token", os.Getenv("GITHUB_TOKEN")); err != nil {
+		return err
+	}
+
+	repository := os.Getenv("GITHUB_REPOSITORY")
+	if repository == "" {
+		return nil
+	}
+
+	owner, repo, ok := strings.Cut(repository, "/")
+	if !ok {
+		return fmt.Errorf("invalid value for GITHUB_REPOSITORY: %s, must be owner/repo", repository)
+	}
+
+	if err := setIfEmpty(flags, "owner", owner); err != nil {
+		return err
+	}
+
+	return setIfEmpty(flags, "repo", repo)
+}
+
+// setIfEmpty sets the flag to the value if the flag is empty.
+func setIfEmpty(flags *pflag.FlagSet, name, value string) error {
+	flag := flags.Lookup(name)
+	if value == "" || flag == nil || flag.Value.String() != "" {
+		return nil
+	}
+
+	if err := flags.Set(name, value); err != nil {
+		return fmt.Errorf("failed to set %s: %w", name, err)
+	}
+
+	return nil
+}
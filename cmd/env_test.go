@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/config"
+)
+
+func TestApplyEnv(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		env          map[string]string
+		targetBranch string
+		groups       []string
+	}{
+		{
+			name:         "the environment overrides the defaults",
+			env:          map[string]string{"BUNDLER_TARGET_BRANCH": "develop"},
+			targetBranch: "develop",
+		},
+		{
+			name:         "flags override the environment",
+			args:         []string{"--target-branch", "release"},
+			env:          map[string]string{"BUNDLER_TARGET_BRANCH": "develop"},
+			targetBranch: "release",
+		},
+		{
+			name:         "empty variables are ignored",
+			env:          map[string]string{"BUNDLER_TARGET_BRANCH": ""},
+			targetBranch: "main",
+		},
+		{
+			name:         "repeated flags take one value per line",
+			env:          map[string]string{"BUNDLER_GROUP": "go:include-ecosystems=go_modules\nactions\n"},
+			targetBranch: "main",
+			groups:       []string{"go:include-ecosystems=go_modules", "actions"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			rootArgs := &rootArgsStruct{}
+			flags := testFlags(rootArgs)
+			require.NoError(t, flags.Parse(tt.args))
+
+			require.NoError(t, applyEnv(flags))
+
+			assert.Equal(t, tt.targetBranch, rootArgs.targetBranch)
+			assert.Equal(t, tt.groups, rootArgs.groups)
+		})
+	}
+}
+
+func TestApplyEnvInvalidValue(t *testing.T) {
+	t.Setenv("BUNDLER_VERIFY_BOTS", "maybe")
+
+	flags := testFlags(&rootArgsStruct{})
+
+	assert.ErrorContains(t, applyEnv(flags), "invalid value for BUNDLER_VERIFY_BOTS")
+}
+
+func TestApplyGitHubEnv(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		env        map[string]string
+		token      string
+		owner      string
+		repo       string
+		errMessage string
+	}{
+		{
+			name:  "the variables of GitHub Actions fill empty flags",
+			env:   map[string]string{"GITHUB_TOKEN": "github", "GITHUB_REPOSITORY": "Skarlso/test"},
+			token: "github",
+			owner: "Skarlso",
+			repo:  "test",
+		},
+		{
+			name:  "empty flags like the inputs of the action don't hide them",
+			args:  []string{"--owner=", "--repo="},
+			env:   map[string]string{"GITHUB_REPOSITORY": "Skarlso/test"},
+			owner: "Skarlso",
+			repo:  "test",
+		},
+		{
+			name:  "flags override them",
+			args:  []string{"--token", "flag", "--owner", "me"},
+			env:   map[string]string{"GITHUB_TOKEN": "github", "GITHUB_REPOSITORY": "Skarlso/test"},
+			token: "flag",
+			owner: "me",
+			repo:  "test",
+		},
+		{
+			name: "BUNDLER variables override them",
+			env: map[string]string{
+				"BUNDLER_TOKEN":     "bundler",
+				"BUNDLER_REPO":      "other",
+				"GITHUB_TOKEN":      "github",
+				"GITHUB_REPOSITORY": "Skarlso/test",
+			},
+			token: "bundler",
+			owner: "Skarlso",
+			repo:  "other",
+		},
+		{
+			name:       "an invalid repository",
+			env:        map[string]string{"GITHUB_REPOSITORY": "test"},
+			errMessage: "invalid value for GITHUB_REPOSITORY: test, must be owner/repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"GITHUB_TOKEN", "GITHUB_REPOSITORY"} {
+				t.Setenv(name, "")
+			}
+
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			rootArgs := &rootArgsStruct{}
+			flags := testFlags(rootArgs)
+			require.NoError(t, flags.Parse(tt.args))
+
+			err := applyEnv(flags)
+			if tt.errMessage != "" {
+				assert.EqualError(t, err, tt.errMessage)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.token, rootArgs.token)
+			assert.Equal(t, tt.owner, rootArgs.owner)
+			assert.Equal(t, tt.repo, rootArgs.repo)
+		})
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	t.Setenv("BUNDLER_TARGET_BRANCH", "env")
+	t.Setenv("BUNDLER_LABELS", "env")
+	t.Setenv("GITHUB_REPOSITORY", "Skarlso/test")
+
+	verifyBots := false
+	file := &config.File{
+		TargetBranch: "file",
+		Labels:       []string{"file"},
+		VerifyBots:   &verifyBots,
+	}
+
+	rootArgs := &rootArgsStruct{}
+	flags := testFlags(rootArgs)
+	require.NoError(t, flags.Parse([]string{"--target-branch", "flag"}))
+	require.NoError(t, applyEnv(flags))
+
+	applyConfigFile(flags, rootArgs, file)
+
+	assert.Equal(t, "flag", rootArgs.targetBranch)
+	assert.Equal(t, []string{"env"}, rootArgs.labels)
+	assert.Equal(t, "Skarlso", rootArgs.owner)
+	assert.False(t, rootArgs.verifyBots)
+}
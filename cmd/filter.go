@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/Skarlso/dependabot-bundler/pkg"
+)
+
+// addFilterFlags registers the flags which select the PRs to bundle.
+func addFilterFlags(flag *pflag.FlagSet, filter *pkg.Filter) {
+	flag.StringSliceVar(
+		&filter.IncludeEcosystems,
+		"include-ecosystems",
+		nil,
+		"--include-ecosystems only bundle updates of these ecosystems, for example go_modules,github_actions",
+	)
+	flag.StringSliceVar(
+		&filter.ExcludeEcosystems,
+		"exclude-ecosystems",
+		nil,
+		"--exclude-ecosystems never bundle updates of these ecosystems",
+	)
+	flag.StringSliceVar(
+		&filter.IncludeDirectories,
+		"include-directories",
+		nil,
+		"--include-directories only bundle updates in directories matching these globs, for example /tools",
+	)
+	flag.StringSliceVar(
+		&filter.ExcludeDirectories,
+		"exclude-directories",
+		nil,
+		"--exclude-directories never bundle updates in directories matching these globs",
+	)
+	flag.StringSliceVar(
+		&filter.IncludeDependencies,
+		"include-dependencies",
+		nil,
+		"--include-dependencies only bundle dependencies matching these globs or re:regexps",
+	)
+	flag.StringSliceVar(
+		&filter.ExcludeDependencies,
+		"exclude-dependencies",
+		nil,
+		"--exclude-dependencies never bundle dependencies matching these globs or re:regexps, for example k8s.io/*",
+	)
+	flag.StringSliceVar(
+		&filter.IncludeLabels,
+		"include-labels",
+		nil,
+		"--include-labels only bundle PRs which carry at least one of these labels",
+	)
+	flag.StringSliceVar(
+		&filter.ExcludeLabels,
+		"exclude-labels",
+		nil,
+		"--exclude-labels never bundle PRs which carry any of these labels",
+	)
+}
+
+// addLevelFlags registers the flags which limit the semantic version level of the bundled updates.
+func addLevelFlags(flag *pflag.FlagSet, rootArgs *rootArgsStruct) {
+	flag.StringVar(
+		&rootArgs.maxLevel,
+		"max-update-level",
+		"",
+		"--max-update-level the highest level of updates to bundle, patch, minor or major, higher ones are listed separately",
+	)
+	flag.BoolVar(
+		&rootArgs.excludeMajor,
+		"exclude-major",
+		false,
+		"--exclude-major leave major updates out of the bundle, same as --max-update-level minor",
+	)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Skarlso/dependabot-bundler/pkg"
+	"github.com/Skarlso/dependabot-bundler/pkg/metadata"
+)
+
+// parseGroups parses the values of --group. A group is defined as a list of key=value pairs separated by
+// semicolons, where the keys are the names of the matching flags, for example:
+//
+//	name=actions;include-ecosystems=github_actions;title=Bundled action updates;labels=ci,actions
+func parseGroups(values []string) ([]pkg.Group, error) {
+	groups := make([]pkg.Group, 0, len(values))
+
+	for _, value := range values {
+		group, err := parseGroup(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for --group %q: %w", value, err)
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+func parseGroup(value string) (pkg.Group, error) {
+	var group pkg.Group
+
+	lists := map[string]*[]string{
+		"labels":               &group.Labels,
+		"include-ecosystems":   &group.Filter.IncludeEcosystems,
+		"exclude-ecosystems":   &group.Filter.ExcludeEcosystems,
+		"include-directories":  &group.Filter.IncludeDirectories,
+		"exclude-directories":  &group.Filter.ExcludeDirectories,
+		"include-dependencies": &group.Filter.IncludeDependencies,
+		"exclude-dependencies": &group.Filter.ExcludeDependencies,
+		"include-labels":       &group.Filter.IncludeLabels,
+		"exclude-labels":       &group.Filter.ExcludeLabels,
+	}
+	strs := map[string]*string{
+		"name":             &group.Name,
+		"title":            &group.PRTitle,
+		"branch":           &group.Branch,
+		"max-update-level": &group.MaxUpdateLevel,
+	}
+
+	for _, pair := range strings.Split(value, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok {
+			return group, fmt.Errorf("missing value for %s", pair)
+		}
+
+		key = strings.TrimSpace(key)
+
+		switch {
+		case lists[key] != nil:
+			*lists[key] = strings.Split(val, ",")
+		case strs[key] != nil:
+			*strs[key] = val
+		case key == "exclude-major":
+			excludeMajor, err := strconv.ParseBool(val)
+			if err != nil {
+				return group, fmt.Errorf("invalid value for exclude-major: %w", err)
+			}
+
+			group.ExcludeMajor = excludeMajor
+		default:
+			return group, fmt.Errorf("unknown key %s", key)
+		}
+	}
+
+	if err := metadata.ValidateLevel(group.MaxUpdateLevel); err != nil {
+		return group, fmt.Errorf("invalid value for max-update-level: %w", err)
+	}
+
+	return group, nil
+}
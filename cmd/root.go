@@ -1,34 +1,77 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
+	"os"
 
 	"github.com/Skarlso/dependabot-bundler/pkg"
+	"github.com/Skarlso/dependabot-bundler/pkg/api"
+	"github.com/Skarlso/dependabot-bundler/pkg/config"
+	"github.com/Skarlso/dependabot-bundler/pkg/forge"
 	"github.com/Skarlso/dependabot-bundler/pkg/logger"
+	"github.com/Skarlso/dependabot-bundler/pkg/metadata"
 	ghau "github.com/Skarlso/dependabot-bundler/pkg/providers/ghaupdater"
 	mu "github.com/Skarlso/dependabot-bundler/pkg/providers/mupdater"
 	"github.com/Skarlso/dependabot-bundler/pkg/providers/pgp"
 	"github.com/Skarlso/dependabot-bundler/pkg/providers/runner"
 	"github.com/google/go-github/v43/github"
 	"github.com/spf13/cobra"
-	"golang.org/x/oauth2"
 )
 
 const defaultKeyBitLength = 4096
 
+// Platforms which can be selected with --forge.
+const (
+	forgeGitHub = "github"
+	forgeGitLab = "gitlab"
+	forgeGitea  = "gitea"
+)
+
 type rootArgsStruct struct {
-	botName      string
-	token        string
-	owner        string
-	repo         string
-	labels       []string
-	targetBranch string
-	authorName   string
-	authorEmail  string
-	prTitle      string
-	verbose      bool
-	pgp          struct {
+	botName        string
+	bots           []string
+	token          string
+	owner          string
+	repo           string
+	labels         []string
+	targetBranch   string
+	authorName     string
+	authorEmail    string
+	prTitle        string
+	verbose        bool
+	closeMode      string
+	commentOnPRs   bool
+	verifyBots     bool
+	verifiedOnly   bool
+	perDep         bool
+	blobSize       int
+	backend        string
+	gitSignKey     string
+	keepOnFail     bool
+	filter         pkg.Filter
+	maxLevel       string
+	excludeMajor   bool
+	groups         []string
+	configPath     string
+	useDependabot  bool
+	dependabotPath string
+	templates      struct {
+		prTitle       string
+		prBody        string
+		commitMessage string
+	}
+	apiURL    string
+	uploadURL string
+	caBundle  string
+	forge     string
+	gitlabURL string
+	giteaURL  string
+	app       struct {
+		id             int64
+		installationID int64
+		privateKey     string
+	}
+	pgp struct {
 		name       string
 		email      string
 		publicKey  string
@@ -46,12 +89,87 @@ func CreateRootCommand() *cobra.Command {
 		Short: "Dependabot bundler action",
 	}
 
-	flag := rootCmd.Flags()
+	// Server Configs, shared with the sub commands
+	persistent := rootCmd.PersistentFlags()
+	persistent.StringVar(&rootArgs.token, "token", "", "--token github token")
+	persistent.StringVar(&rootArgs.owner, "owner", "", "--owner github organization / owner")
+	persistent.StringVar(&rootArgs.repo, "repo", "", "--repo github repository")
+	persistent.StringVar(
+		&rootArgs.apiURL,
+		"api-url",
+		"",
+		"--api-url the API URL of a GitHub Enterprise Server, for example https://github.example.com/api/v3/",
+	)
+	persistent.StringVar(
+		&rootArgs.uploadURL,
+		"upload-url",
+		"",
+		"--upload-url the upload URL of a GitHub Enterprise Server, defaults to the host of --api-url",
+	)
+	persistent.StringVar(
+		&rootArgs.caBundle,
+		"ca-bundle",
+		"",
+		"--ca-bundle path to a PEM file with certificates to trust in addition to the ones of the system",
+	)
+	persistent.StringVar(
+		&rootArgs.forge,
+		"forge",
+		forgeGitHub,
+		"--forge the platform of the repository, github, gitlab or gitea, which also covers Forgejo",
+	)
+	persistent.StringVar(
+		&rootArgs.gitlabURL,
+		"gitlab-url",
+		forge.DefaultGitLabURL,
+		"--gitlab-url the URL of the GitLab instance, defaults to https://gitlab.com",
+	)
+	persistent.StringVar(
+		&rootArgs.giteaURL,
+		"gitea-url",
+		"",
+		"--gitea-url the URL of the Gitea or Forgejo instance, for example https://codeberg.org",
+	)
+	persistent.Int64Var(&rootArgs.app.id, "app-id", 0, "--app-id authenticate as this GitHub App instead of with --token")
+	persistent.Int64Var(
+		&rootArgs.app.installationID,
+		"app-installation-id",
+		0,
+		"--app-installation-id the ID of the installation of the GitHub App in the repository owner's account",
+	)
+	persistent.StringVar(
+		&rootArgs.app.privateKey,
+		"app-private-key",
+		"",
+		"--app-private-key the PEM encoded private key of the GitHub App",
+	)
+	persistent.BoolVarP(
+		&rootArgs.verbose,
+		"verbose",
+		"v",
+		false,
+		"--verbose|-v if enabled, will output extra debug information",
+	)
 
-	// Server Configs
-	flag.StringVar(&rootArgs.token, "token", "", "--token github token")
-	flag.StringVar(&rootArgs.owner, "owner", "", "--owner github organization / owner")
-	flag.StringVar(&rootArgs.repo, "repo", "", "--repo github repository")
+	flag := rootCmd.Flags()
+	flag.StringVar(
+		&rootArgs.configPath,
+		"config",
+		config.DefaultPath,
+		"--config path to the config file of the bundler, flags which are set override its values",
+	)
+	flag.BoolVar(
+		&rootArgs.useDependabot,
+		"use-dependabot-config",
+		false,
+		"--use-dependabot-config take the ecosystems, groups and target branch from the Dependabot configuration",
+	)
+	flag.StringVar(
+		&rootArgs.dependabotPath,
+		"dependabot-config",
+		config.DefaultDependabotPath,
+		"--dependabot-config path to the Dependabot configuration used with --use-dependabot-config",
+	)
 	flag.StringSliceVar(
 		&rootArgs.labels,
 		"labels",
@@ -64,6 +182,12 @@ func CreateRootCommand() *cobra.Command {
 		"app/dependabot",
 		"--bot-name the name of the bot, default is app/dependabot",
 	)
+	flag.StringSliceVar(
+		&rootArgs.bots,
+		"bots",
+		nil,
+		"--bots the logins of several bots to bundle together, each optionally with =dependabot or =renovate",
+	)
 	flag.StringVar(
 		&rootArgs.authorName,
 		"author-name",
@@ -88,12 +212,85 @@ func CreateRootCommand() *cobra.Command {
 		"Dependabot Bundler PR",
 		"--pr-title the title of the PR that will be created",
 	)
-	flag.BoolVarP(
-		&rootArgs.verbose,
-		"verbose",
-		"v",
+	flag.StringVar(
+		&rootArgs.closeMode,
+		"close-superseded",
+		pkg.CloseNever,
+		"--close-superseded comment on and close the bundled PRs, either on-open or on-merge of the bundle PR",
+	)
+	flag.BoolVar(
+		&rootArgs.commentOnPRs,
+		"comment-on-prs",
 		false,
-		"--verbose|-v if enabled, will output extra debug information",
+		"--comment-on-prs leave a comment with a link to the bundle on each bundled PR",
+	)
+	flag.BoolVar(
+		&rootArgs.verifyBots,
+		"verify-bots",
+		true,
+		"--verify-bots skip PRs which weren't opened by the bot account from a branch of the repository itself",
+	)
+	flag.BoolVar(
+		&rootArgs.verifiedOnly,
+		"require-verified-commits",
+		false,
+		"--require-verified-commits skip PRs with commits which don't carry a verified signature, GitHub only",
+	)
+	flag.StringVar(
+		&rootArgs.templates.prTitle,
+		"pr-title-template",
+		"",
+		"--pr-title-template path to a text/template file to render the title of the PR with, overrides --pr-title",
+	)
+	flag.StringVar(
+		&rootArgs.templates.prBody,
+		"pr-body-template",
+		"",
+		"--pr-body-template path to a text/template file to render the description of the PR with",
+	)
+	flag.StringVar(
+		&rootArgs.templates.commitMessage,
+		"commit-message-template",
+		"",
+		"--commit-message-template path to a text/template file to render the commit message with",
+	)
+	flag.BoolVar(
+		&rootArgs.perDep,
+		"commit-per-dependency",
+		false,
+		"--commit-per-dependency create a separate commit for each bundled dependency",
+	)
+	flag.IntVar(
+		&rootArgs.blobSize,
+		"blob-threshold",
+		pkg.DefaultBlobThreshold,
+		"--blob-threshold size in bytes above which files are uploaded through the blobs API",
+	)
+	flag.StringVar(
+		&rootArgs.backend,
+		"backend",
+		pkg.BackendAPI,
+		"--backend how to create and push the commits, api uses the GitHub API, git uses the local git CLI",
+	)
+	flag.StringVar(
+		&rootArgs.gitSignKey,
+		"git-signing-key",
+		"",
+		"--git-signing-key the ID of the GPG key the git backend signs commits with, must be known to git",
+	)
+	flag.BoolVar(
+		&rootArgs.keepOnFail,
+		"keep-on-failure",
+		false,
+		"--keep-on-failure keep created branches, PRs and the worktree if the run fails, for debugging",
+	)
+	addFilterFlags(flag, &rootArgs.filter)
+	addLevelFlags(flag, rootArgs)
+	flag.StringArrayVar(
+		&rootArgs.groups,
+		"group",
+		nil,
+		"--group a separate bundle as key=value pairs separated by ;, e.g. name=actions;include-ecosystems=github_actions",
 	)
 	flag.StringVar(
 		&rootArgs.pgp.name,
@@ -132,24 +329,57 @@ func CreateRootCommand() *cobra.Command {
 		"--signing-key-passphrase the passphrase to use for the signing key",
 	)
 
+	// every flag can also be set with its BUNDLER_* environment variable
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return applyEnv(cmd.Flags())
+	}
 	rootCmd.RunE = rootRunE(rootArgs)
+	rootCmd.AddCommand(createCleanupCommand(rootArgs))
 
 	return rootCmd
 }
 
 func rootRunE(rootArgs *rootArgsStruct) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		ts := oauth2.StaticTokenSource(
-			&oauth2.Token{AccessToken: rootArgs.token},
-		)
-		tc := oauth2.NewClient(ctx, ts)
-		client := github.NewClient(tc)
-
-		// setup logger
-		var log logger.Logger = &logger.QuiteLogger{}
-		if rootArgs.verbose {
-			log = &logger.VerboseLogger{}
+		file, dependabot, err := loadConfigs(cmd.Flags(), rootArgs)
+		if err != nil {
+			return err
+		}
+
+		if err := validateArgs(rootArgs); err != nil {
+			return err
+		}
+
+		log := newLogger(rootArgs)
+
+		groups, ungrouped, err := resolveGroups(rootArgs, file, dependabot, log)
+		if err != nil {
+			return err
+		}
+
+		bots, err := resolveBots(rootArgs, file)
+		if err != nil {
+			return err
+		}
+
+		prTitleTemplate, err := readTemplate(rootArgs.templates.prTitle)
+		if err != nil {
+			return err
+		}
+
+		prBodyTemplate, err := readTemplate(rootArgs.templates.prBody)
+		if err != nil {
+			return err
+		}
+
+		commitMessageTemplate, err := readTemplate(rootArgs.templates.commitMessage)
+		if err != nil {
+			return err
+		}
+
+		client, gitForge, err := newClients(rootArgs)
+		if err != nil {
+			return err
 		}
 
 		// setup GitHub actions updater
@@ -160,21 +390,42 @@ func rootRunE(rootArgs *rootArgsStruct) func(cmd *cobra.Command, args []string)
 		updater := mu.NewGoUpdater(log, actionsUpdater, osRunner)
 
 		bundler := pkg.NewBundler(pkg.Config{
-			Labels:       rootArgs.labels,
-			TargetBranch: rootArgs.targetBranch,
-			Owner:        rootArgs.owner,
-			Repo:         rootArgs.repo,
-			BotName:      rootArgs.botName,
-			AuthorEmail:  rootArgs.authorEmail,
-			AuthorName:   rootArgs.authorName,
-			PRTitle:      rootArgs.prTitle,
-			Issues:       client.Issues,
-			Pulls:        client.PullRequests,
-			Git:          client.Git,
-			Repositories: client.Repositories,
-			Updater:      updater,
-			Logger:       log,
-			Runner:       osRunner,
+			Labels:                 rootArgs.labels,
+			TargetBranch:           rootArgs.targetBranch,
+			Owner:                  rootArgs.owner,
+			Repo:                   rootArgs.repo,
+			BotName:                rootArgs.botName,
+			Bots:                   bots,
+			AuthorEmail:            rootArgs.authorEmail,
+			AuthorName:             rootArgs.authorName,
+			PRTitle:                rootArgs.prTitle,
+			CloseSuperseded:        rootArgs.closeMode,
+			CommentOnPRs:           rootArgs.commentOnPRs,
+			VerifyBots:             rootArgs.verifyBots,
+			RequireVerifiedCommits: rootArgs.verifiedOnly,
+			Enterprise:             rootArgs.apiURL != "",
+			PRTitleTemplate:        prTitleTemplate,
+			PRBodyTemplate:         prBodyTemplate,
+			CommitMessageTemplate:  commitMessageTemplate,
+			CommitPerDependency:    rootArgs.perDep,
+			BlobThreshold:          rootArgs.blobSize,
+			Backend:                rootArgs.backend,
+			GitSigningKey:          rootArgs.gitSignKey,
+			KeepOnFailure:          rootArgs.keepOnFail,
+			Filter:                 rootArgs.filter,
+			MaxUpdateLevel:         rootArgs.maxLevel,
+			ExcludeMajor:           rootArgs.excludeMajor,
+			Groups:                 groups,
+			BundleUngrouped:        ungrouped,
+			Declared:               declarations(dependabot),
+			Forge:                  gitForge,
+			Issues:                 client.Issues,
+			Pulls:                  client.PullRequests,
+			Git:                    client.Git,
+			Repositories:           client.Repositories,
+			Updater:                updater,
+			Logger:                 log,
+			Runner:                 osRunner,
 		})
 
 		if rootArgs.pgp.publicKey != "" {
@@ -196,3 +447,139 @@ func rootRunE(rootArgs *rootArgsStruct) func(cmd *cobra.Command, args []string)
 		return nil
 	}
 }
+
+// validateArgs checks the values of the flags which only accept a fixed set of values.
+func validateArgs(rootArgs *rootArgsStruct) error {
+	switch rootArgs.closeMode {
+	case pkg.CloseNever, pkg.CloseOnOpen, pkg.CloseOnMerge:
+	default:
+		return fmt.Errorf("invalid value for --close-superseded: %s, must be on-open or on-merge", rootArgs.closeMode)
+	}
+
+	if rootArgs.backend != pkg.BackendAPI && rootArgs.backend != pkg.BackendGit {
+		return fmt.Errorf("invalid value for --backend: %s, must be api or git", rootArgs.backend)
+	}
+
+	if err := validateForge(rootArgs); err != nil {
+		return err
+	}
+
+	if err := metadata.ValidateLevel(rootArgs.maxLevel); err != nil {
+		return fmt.Errorf("invalid value for --max-update-level: %w", err)
+	}
+
+	return nil
+}
+
+// readTemplate returns the content of a template file. An empty path results in an empty template.
+func readTemplate(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template file %s: %w", path, err)
+	}
+
+	return string(content), nil
+}
+
+// validateForge checks the value of --forge and the URL of the selected platform.
+func validateForge(rootArgs *rootArgsStruct) error {
+	switch rootArgs.forge {
+	case forgeGitHub, forgeGitLab:
+	case forgeGitea:
+		if rootArgs.giteaURL == "" {
+			return fmt.Errorf("--gitea-url is required with --forge %s", forgeGitea)
+		}
+	default:
+		return fmt.Errorf("invalid value for --forge: %s, must be github, gitlab or gitea", rootArgs.forge)
+	}
+
+	return nil
+}
+
+// newClients creates the GitHub client and, if the repository is not on GitHub, the Forge to use instead
+// of it. Then, the GitHub client is unauthenticated and only used to resolve the versions of GitHub actions.
+func newClients(rootArgs *rootArgsStruct) (*github.Client, forge.Forge, error) {
+	if rootArgs.forge == forgeGitHub {
+		client, err := newClient(rootArgs)
+
+		return client, nil, err
+	}
+
+	caBundle, err := readCABundle(rootArgs.caBundle)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpClient, err := api.NewHTTPClient(caBundle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create %s client: %w", rootArgs.forge, err)
+	}
+
+	if rootArgs.forge == forgeGitea {
+		return github.NewClient(nil), &forge.Gitea{
+			BaseURL: rootArgs.giteaURL,
+			Owner:   rootArgs.owner,
+			Repo:    rootArgs.repo,
+			Token:   rootArgs.token,
+			Client:  httpClient,
+		}, nil
+	}
+
+	return github.NewClient(nil), &forge.GitLab{
+		BaseURL: rootArgs.gitlabURL,
+		Project: rootArgs.owner + "/" + rootArgs.repo,
+		Token:   rootArgs.token,
+		Client:  httpClient,
+	}, nil
+}
+
+// newClient creates a GitHub client which authenticates as the GitHub App if one is configured, otherwise
+// with the token.
+func newClient(rootArgs *rootArgsStruct) (*github.Client, error) {
+	caBundle, err := readCABundle(rootArgs.caBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := api.NewClient(api.ClientOptions{
+		Token:             rootArgs.token,
+		AppID:             rootArgs.app.id,
+		AppInstallationID: rootArgs.app.installationID,
+		AppPrivateKey:     []byte(rootArgs.app.privateKey),
+		APIURL:            rootArgs.apiURL,
+		UploadURL:         rootArgs.uploadURL,
+		CABundle:          caBundle,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	return client, nil
+}
+
+// readCABundle returns the content of the CA bundle file. An empty path results in no bundle.
+func readCABundle(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	return content, nil
+}
+
+// newLogger creates the logger depending on whether verbose output is enabled.
+func newLogger(rootArgs *rootArgsStruct) logger.Logger {
+	if rootArgs.verbose {
+		return &logger.VerboseLogger{}
+	}
+
+	return &logger.QuiteLogger{}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v43/github"
+	"golang.org/x/oauth2"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/auth"
+)
+
+// ClientOptions configures the GitHub client created by NewClient.
+type ClientOptions struct {
+	// Token is a personal access token or the token of a workflow.
+	Token string
+	// AppID, AppInstallationID and AppPrivateKey authenticate as a GitHub App instead of with the token,
+	// if AppID is set.
+	AppID             int64
+	AppInstallationID int64
+	AppPrivateKey     []byte
+	// APIURL is the URL of the API of a GitHub Enterprise Server, for example https://github.example.com/api/v3/.
+	// If empty, github.com is used.
+	APIURL string
+	// UploadURL is the upload URL of a GitHub Enterprise Server. Defaults to the host of APIURL.
+	UploadURL string
+	// CABundle contains PEM encoded certificates which are trusted in addition to the ones of the system.
+	CABundle []byte
+}
+
+// NewClient creates an authenticated GitHub client. Either a token or a GitHub App is required.
+func NewClient(opts ClientOptions) (*github.Client, error) {
+	if opts.Token == "" && opts.AppID == 0 {
+		return nil, errors.New("no credentials, either a token or a GitHub App is required")
+	}
+
+	base, err := NewHTTPClient(opts.CABundle)
+	if err != nil {
+		return nil, err
+	}
+
+	// an unauthenticated client resolves the URLs of the API, the app needs them to mint tokens
+	client, err := newGitHubClient(opts, base)
+	if err != nil {
+		return nil, err
+	}
+
+	source := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: opts.Token})
+
+	if opts.AppID != 0 {
+		if opts.AppInstallationID == 0 || len(opts.AppPrivateKey) == 0 {
+			return nil, errors.New("a GitHub App requires an installation ID and a private key")
+		}
+
+		source, err = auth.NewAppTokenSource(
+			opts.AppID, opts.AppInstallationID, opts.AppPrivateKey, client.BaseURL.String(), base,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up GitHub App authentication: %w", err)
+		}
+	}
+
+	return newGitHubClient(opts, &http.Client{
+		Transport: &oauth2.Transport{Source: source, Base: base.Transport},
+	})
+}
+
+// newGitHubClient creates a client for github.com, or for a GitHub Enterprise Server if an API URL is set.
+func newGitHubClient(opts ClientOptions, httpClient *http.Client) (*github.Client, error) {
+	if opts.APIURL == "" {
+		return github.NewClient(httpClient), nil
+	}
+
+	uploadURL := opts.UploadURL
+	if uploadURL == "" {
+		uploadURL = strings.TrimSuffix(strings.TrimSuffix(opts.APIURL, "/"), "/api/v3")
+	}
+
+	client, err := github.NewEnterpriseClient(opts.APIURL, uploadURL, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create enterprise client: %w", err)
+	}
+
+	return client, nil
+}
+
+// NewHTTPClient returns an HTTP client which trusts the certificates of the bundle in addition to the ones
+// of the system.
+func NewHTTPClient(caBundle []byte) (*http.Client, error) {
+	if len(caBundle) == 0 {
+		return &http.Client{Transport: http.DefaultTransport}, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return nil, errors.New("failed to add CA bundle: no certificates found")
+	}
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("failed to add CA bundle: unexpected default transport")
+	}
+
+	transport = transport.Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,114 @@
+package api_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/api"
+)
+
+// enterpriseServer stands in for the API of a GitHub Enterprise Server, which is served under /api/v3/.
+func enterpriseServer(t *testing.T) (*httptest.Server, []byte) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/app/installations/2/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": "installation-token", "expires_at": %q}`, time.Now().Add(time.Hour).Format(time.RFC3339))
+	})
+	mux.HandleFunc("/api/v3/repos/owner/repo/commits/sha", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"sha": "sha", "commit": {"message": %q}}`, r.Header.Get("Authorization"))
+	})
+
+	server := httptest.NewUnstartedServer(mux)
+	// the handshakes of untrusted clients fail on purpose
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	return server, caBundle
+}
+
+func TestNewClientEnterprise(t *testing.T) {
+	server, caBundle := enterpriseServer(t)
+
+	client, err := api.NewClient(api.ClientOptions{
+		Token:    "token",
+		APIURL:   server.URL,
+		CABundle: caBundle,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/api/v3/", client.BaseURL.String())
+	assert.Equal(t, server.URL+"/api/uploads/", client.UploadURL.String())
+
+	commit, _, err := client.Repositories.GetCommit(context.Background(), "owner", "repo", "sha", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token", commit.GetCommit().GetMessage())
+}
+
+func TestNewClientEnterpriseUploadURL(t *testing.T) {
+	client, err := api.NewClient(api.ClientOptions{
+		Token:     "token",
+		APIURL:    "https://github.example.com/api/v3/",
+		UploadURL: "https://upload.github.example.com/api/uploads/",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/api/v3/", client.BaseURL.String())
+	assert.Equal(t, "https://upload.github.example.com/api/uploads/", client.UploadURL.String())
+}
+
+func TestNewClientEnterpriseApp(t *testing.T) {
+	server, caBundle := enterpriseServer(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	client, err := api.NewClient(api.ClientOptions{
+		AppID:             1,
+		AppInstallationID: 2,
+		AppPrivateKey:     pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		APIURL:            server.URL + "/api/v3/",
+		CABundle:          caBundle,
+	})
+	require.NoError(t, err)
+
+	commit, _, err := client.Repositories.GetCommit(context.Background(), "owner", "repo", "sha", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "token installation-token", commit.GetCommit().GetMessage())
+}
+
+func TestNewClientUntrustedCertificate(t *testing.T) {
+	server, _ := enterpriseServer(t)
+
+	client, err := api.NewClient(api.ClientOptions{Token: "token", APIURL: server.URL})
+	require.NoError(t, err)
+
+	_, _, err = client.Repositories.GetCommit(context.Background(), "owner", "repo", "sha", nil)
+	assert.ErrorContains(t, err, "certificate")
+}
+
+func TestNewClientInvalidOptions(t *testing.T) {
+	_, err := api.NewClient(api.ClientOptions{})
+	assert.ErrorContains(t, err, "either a token or a GitHub App is required")
+
+	_, err = api.NewClient(api.ClientOptions{Token: "token", CABundle: []byte("not a certificate")})
+	assert.ErrorContains(t, err, "no certificates found")
+
+	_, err = api.NewClient(api.ClientOptions{AppID: 1})
+	assert.ErrorContains(t, err, "requires an installation ID and a private key")
+}
@@ -10,6 +10,24 @@ import (
 )
 
 type FakeGit struct {
+	CreateBlobStub        func(context.Context, string, string, *github.Blob) (*github.Blob, *github.Response, error)
+	createBlobMutex       sync.RWMutex
+	createBlobArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *github.Blob
+	}
+	createBlobReturns struct {
+		result1 *github.Blob
+		result2 *github.Response
+		result3 error
+	}
+	createBlobReturnsOnCall map[int]struct {
+		result1 *github.Blob
+		result2 *github.Response
+		result3 error
+	}
 	CreateCommitStub        func(context.Context, string, string, *github.Commit) (*github.Commit, *github.Response, error)
 	createCommitMutex       sync.RWMutex
 	createCommitArgsForCall []struct {
@@ -65,6 +83,22 @@ type FakeGit struct {
 		result2 *github.Response
 		result3 error
 	}
+	DeleteRefStub        func(context.Context, string, string, string) (*github.Response, error)
+	deleteRefMutex       sync.RWMutex
+	deleteRefArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	deleteRefReturns struct {
+		result1 *github.Response
+		result2 error
+	}
+	deleteRefReturnsOnCall map[int]struct {
+		result1 *github.Response
+		result2 error
+	}
 	GetRefStub        func(context.Context, string, string, string) (*github.Reference, *github.Response, error)
 	getRefMutex       sync.RWMutex
 	getRefArgsForCall []struct {
@@ -83,6 +117,24 @@ type FakeGit struct {
 		result2 *github.Response
 		result3 error
 	}
+	ListMatchingRefsStub        func(context.Context, string, string, *github.ReferenceListOptions) ([]*github.Reference, *github.Response, error)
+	listMatchingRefsMutex       sync.RWMutex
+	listMatchingRefsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *github.ReferenceListOptions
+	}
+	listMatchingRefsReturns struct {
+		result1 []*github.Reference
+		result2 *github.Response
+		result3 error
+	}
+	listMatchingRefsReturnsOnCall map[int]struct {
+		result1 []*github.Reference
+		result2 *github.Response
+		result3 error
+	}
 	UpdateRefStub        func(context.Context, string, string, *github.Reference, bool) (*github.Reference, *github.Response, error)
 	updateRefMutex       sync.RWMutex
 	updateRefArgsForCall []struct {
@@ -106,6 +158,76 @@ type FakeGit struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeGit) CreateBlob(arg1 context.Context, arg2 string, arg3 string, arg4 *github.Blob) (*github.Blob, *github.Response, error) {
+	fake.createBlobMutex.Lock()
+	ret, specificReturn := fake.createBlobReturnsOnCall[len(fake.createBlobArgsForCall)]
+	fake.createBlobArgsForCall = append(fake.createBlobArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *github.Blob
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CreateBlobStub
+	fakeReturns := fake.createBlobReturns
+	fake.recordInvocation("CreateBlob", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createBlobMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeGit) CreateBlobCallCount() int {
+	fake.createBlobMutex.RLock()
+	defer fake.createBlobMutex.RUnlock()
+	return len(fake.createBlobArgsForCall)
+}
+
+func (fake *FakeGit) CreateBlobCalls(stub func(context.Context, string, string, *github.Blob) (*github.Blob, *github.Response, error)) {
+	fake.createBlobMutex.Lock()
+	defer fake.createBlobMutex.Unlock()
+	fake.CreateBlobStub = stub
+}
+
+func (fake *FakeGit) CreateBlobArgsForCall(i int) (context.Context, string, string, *github.Blob) {
+	fake.createBlobMutex.RLock()
+	defer fake.createBlobMutex.RUnlock()
+	argsForCall := fake.createBlobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeGit) CreateBlobReturns(result1 *github.Blob, result2 *github.Response, result3 error) {
+	fake.createBlobMutex.Lock()
+	defer fake.createBlobMutex.Unlock()
+	fake.CreateBlobStub = nil
+	fake.createBlobReturns = struct {
+		result1 *github.Blob
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeGit) CreateBlobReturnsOnCall(i int, result1 *github.Blob, result2 *github.Response, result3 error) {
+	fake.createBlobMutex.Lock()
+	defer fake.createBlobMutex.Unlock()
+	fake.CreateBlobStub = nil
+	if fake.createBlobReturnsOnCall == nil {
+		fake.createBlobReturnsOnCall = make(map[int]struct {
+			result1 *github.Blob
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.createBlobReturnsOnCall[i] = struct {
+		result1 *github.Blob
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeGit) CreateCommit(arg1 context.Context, arg2 string, arg3 string, arg4 *github.Commit) (*github.Commit, *github.Response, error) {
 	fake.createCommitMutex.Lock()
 	ret, specificReturn := fake.createCommitReturnsOnCall[len(fake.createCommitArgsForCall)]
@@ -322,6 +444,73 @@ func (fake *FakeGit) CreateTreeReturnsOnCall(i int, result1 *github.Tree, result
 	}{result1, result2, result3}
 }
 
+func (fake *FakeGit) DeleteRef(arg1 context.Context, arg2 string, arg3 string, arg4 string) (*github.Response, error) {
+	fake.deleteRefMutex.Lock()
+	ret, specificReturn := fake.deleteRefReturnsOnCall[len(fake.deleteRefArgsForCall)]
+	fake.deleteRefArgsForCall = append(fake.deleteRefArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DeleteRefStub
+	fakeReturns := fake.deleteRefReturns
+	fake.recordInvocation("DeleteRef", []interface{}{arg1, arg2, arg3, arg4})
+	fake.deleteRefMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGit) DeleteRefCallCount() int {
+	fake.deleteRefMutex.RLock()
+	defer fake.deleteRefMutex.RUnlock()
+	return len(fake.deleteRefArgsForCall)
+}
+
+func (fake *FakeGit) DeleteRefCalls(stub func(context.Context, string, string, string) (*github.Response, error)) {
+	fake.deleteRefMutex.Lock()
+	defer fake.deleteRefMutex.Unlock()
+	fake.DeleteRefStub = stub
+}
+
+func (fake *FakeGit) DeleteRefArgsForCall(i int) (context.Context, string, string, string) {
+	fake.deleteRefMutex.RLock()
+	defer fake.deleteRefMutex.RUnlock()
+	argsForCall := fake.deleteRefArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeGit) DeleteRefReturns(result1 *github.Response, result2 error) {
+	fake.deleteRefMutex.Lock()
+	defer fake.deleteRefMutex.Unlock()
+	fake.DeleteRefStub = nil
+	fake.deleteRefReturns = struct {
+		result1 *github.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGit) DeleteRefReturnsOnCall(i int, result1 *github.Response, result2 error) {
+	fake.deleteRefMutex.Lock()
+	defer fake.deleteRefMutex.Unlock()
+	fake.DeleteRefStub = nil
+	if fake.deleteRefReturnsOnCall == nil {
+		fake.deleteRefReturnsOnCall = make(map[int]struct {
+			result1 *github.Response
+			result2 error
+		})
+	}
+	fake.deleteRefReturnsOnCall[i] = struct {
+		result1 *github.Response
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeGit) GetRef(arg1 context.Context, arg2 string, arg3 string, arg4 string) (*github.Reference, *github.Response, error) {
 	fake.getRefMutex.Lock()
 	ret, specificReturn := fake.getRefReturnsOnCall[len(fake.getRefArgsForCall)]
@@ -392,6 +581,76 @@ func (fake *FakeGit) GetRefReturnsOnCall(i int, result1 *github.Reference, resul
 	}{result1, result2, result3}
 }
 
+func (fake *FakeGit) ListMatchingRefs(arg1 context.Context, arg2 string, arg3 string, arg4 *github.ReferenceListOptions) ([]*github.Reference, *github.Response, error) {
+	fake.listMatchingRefsMutex.Lock()
+	ret, specificReturn := fake.listMatchingRefsReturnsOnCall[len(fake.listMatchingRefsArgsForCall)]
+	fake.listMatchingRefsArgsForCall = append(fake.listMatchingRefsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *github.ReferenceListOptions
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ListMatchingRefsStub
+	fakeReturns := fake.listMatchingRefsReturns
+	fake.recordInvocation("ListMatchingRefs", []interface{}{arg1, arg2, arg3, arg4})
+	fake.listMatchingRefsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeGit) ListMatchingRefsCallCount() int {
+	fake.listMatchingRefsMutex.RLock()
+	defer fake.listMatchingRefsMutex.RUnlock()
+	return len(fake.listMatchingRefsArgsForCall)
+}
+
+func (fake *FakeGit) ListMatchingRefsCalls(stub func(context.Context, string, string, *github.ReferenceListOptions) ([]*github.Reference, *github.Response, error)) {
+	fake.listMatchingRefsMutex.Lock()
+	defer fake.listMatchingRefsMutex.Unlock()
+	fake.ListMatchingRefsStub = stub
+}
+
+func (fake *FakeGit) ListMatchingRefsArgsForCall(i int) (context.Context, string, string, *github.ReferenceListOptions) {
+	fake.listMatchingRefsMutex.RLock()
+	defer fake.listMatchingRefsMutex.RUnlock()
+	argsForCall := fake.listMatchingRefsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeGit) ListMatchingRefsReturns(result1 []*github.Reference, result2 *github.Response, result3 error) {
+	fake.listMatchingRefsMutex.Lock()
+	defer fake.listMatchingRefsMutex.Unlock()
+	fake.ListMatchingRefsStub = nil
+	fake.listMatchingRefsReturns = struct {
+		result1 []*github.Reference
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeGit) ListMatchingRefsReturnsOnCall(i int, result1 []*github.Reference, result2 *github.Response, result3 error) {
+	fake.listMatchingRefsMutex.Lock()
+	defer fake.listMatchingRefsMutex.Unlock()
+	fake.ListMatchingRefsStub = nil
+	if fake.listMatchingRefsReturnsOnCall == nil {
+		fake.listMatchingRefsReturnsOnCall = make(map[int]struct {
+			result1 []*github.Reference
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.listMatchingRefsReturnsOnCall[i] = struct {
+		result1 []*github.Reference
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeGit) UpdateRef(arg1 context.Context, arg2 string, arg3 string, arg4 *github.Reference, arg5 bool) (*github.Reference, *github.Response, error) {
 	fake.updateRefMutex.Lock()
 	ret, specificReturn := fake.updateRefReturnsOnCall[len(fake.updateRefArgsForCall)]
@@ -466,14 +725,20 @@ func (fake *FakeGit) UpdateRefReturnsOnCall(i int, result1 *github.Reference, re
 func (fake *FakeGit) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createBlobMutex.RLock()
+	defer fake.createBlobMutex.RUnlock()
 	fake.createCommitMutex.RLock()
 	defer fake.createCommitMutex.RUnlock()
 	fake.createRefMutex.RLock()
 	defer fake.createRefMutex.RUnlock()
 	fake.createTreeMutex.RLock()
 	defer fake.createTreeMutex.RUnlock()
+	fake.deleteRefMutex.RLock()
+	defer fake.deleteRefMutex.RUnlock()
 	fake.getRefMutex.RLock()
 	defer fake.getRefMutex.RUnlock()
+	fake.listMatchingRefsMutex.RLock()
+	defer fake.listMatchingRefsMutex.RUnlock()
 	fake.updateRefMutex.RLock()
 	defer fake.updateRefMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -29,6 +29,44 @@ type FakeIssues struct {
 		result2 *github.Response
 		result3 error
 	}
+	CreateCommentStub        func(context.Context, string, string, int, *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	createCommentMutex       sync.RWMutex
+	createCommentArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 *github.IssueComment
+	}
+	createCommentReturns struct {
+		result1 *github.IssueComment
+		result2 *github.Response
+		result3 error
+	}
+	createCommentReturnsOnCall map[int]struct {
+		result1 *github.IssueComment
+		result2 *github.Response
+		result3 error
+	}
+	EditCommentStub        func(context.Context, string, string, int64, *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	editCommentMutex       sync.RWMutex
+	editCommentArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int64
+		arg5 *github.IssueComment
+	}
+	editCommentReturns struct {
+		result1 *github.IssueComment
+		result2 *github.Response
+		result3 error
+	}
+	editCommentReturnsOnCall map[int]struct {
+		result1 *github.IssueComment
+		result2 *github.Response
+		result3 error
+	}
 	ListByRepoStub        func(context.Context, string, string, *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error)
 	listByRepoMutex       sync.RWMutex
 	listByRepoArgsForCall []struct {
@@ -47,6 +85,25 @@ type FakeIssues struct {
 		result2 *github.Response
 		result3 error
 	}
+	ListCommentsStub        func(context.Context, string, string, int, *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error)
+	listCommentsMutex       sync.RWMutex
+	listCommentsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 *github.IssueListCommentsOptions
+	}
+	listCommentsReturns struct {
+		result1 []*github.IssueComment
+		result2 *github.Response
+		result3 error
+	}
+	listCommentsReturnsOnCall map[int]struct {
+		result1 []*github.IssueComment
+		result2 *github.Response
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -127,6 +184,148 @@ func (fake *FakeIssues) AddLabelsToIssueReturnsOnCall(i int, result1 []*github.L
 	}{result1, result2, result3}
 }
 
+func (fake *FakeIssues) CreateComment(arg1 context.Context, arg2 string, arg3 string, arg4 int, arg5 *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	fake.createCommentMutex.Lock()
+	ret, specificReturn := fake.createCommentReturnsOnCall[len(fake.createCommentArgsForCall)]
+	fake.createCommentArgsForCall = append(fake.createCommentArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 *github.IssueComment
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.CreateCommentStub
+	fakeReturns := fake.createCommentReturns
+	fake.recordInvocation("CreateComment", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.createCommentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeIssues) CreateCommentCallCount() int {
+	fake.createCommentMutex.RLock()
+	defer fake.createCommentMutex.RUnlock()
+	return len(fake.createCommentArgsForCall)
+}
+
+func (fake *FakeIssues) CreateCommentCalls(stub func(context.Context, string, string, int, *github.IssueComment) (*github.IssueComment, *github.Response, error)) {
+	fake.createCommentMutex.Lock()
+	defer fake.createCommentMutex.Unlock()
+	fake.CreateCommentStub = stub
+}
+
+func (fake *FakeIssues) CreateCommentArgsForCall(i int) (context.Context, string, string, int, *github.IssueComment) {
+	fake.createCommentMutex.RLock()
+	defer fake.createCommentMutex.RUnlock()
+	argsForCall := fake.createCommentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeIssues) CreateCommentReturns(result1 *github.IssueComment, result2 *github.Response, result3 error) {
+	fake.createCommentMutex.Lock()
+	defer fake.createCommentMutex.Unlock()
+	fake.CreateCommentStub = nil
+	fake.createCommentReturns = struct {
+		result1 *github.IssueComment
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeIssues) CreateCommentReturnsOnCall(i int, result1 *github.IssueComment, result2 *github.Response, result3 error) {
+	fake.createCommentMutex.Lock()
+	defer fake.createCommentMutex.Unlock()
+	fake.CreateCommentStub = nil
+	if fake.createCommentReturnsOnCall == nil {
+		fake.createCommentReturnsOnCall = make(map[int]struct {
+			result1 *github.IssueComment
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.createCommentReturnsOnCall[i] = struct {
+		result1 *github.IssueComment
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeIssues) EditComment(arg1 context.Context, arg2 string, arg3 string, arg4 int64, arg5 *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	fake.editCommentMutex.Lock()
+	ret, specificReturn := fake.editCommentReturnsOnCall[len(fake.editCommentArgsForCall)]
+	fake.editCommentArgsForCall = append(fake.editCommentArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int64
+		arg5 *github.IssueComment
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.EditCommentStub
+	fakeReturns := fake.editCommentReturns
+	fake.recordInvocation("EditComment", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.editCommentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeIssues) EditCommentCallCount() int {
+	fake.editCommentMutex.RLock()
+	defer fake.editCommentMutex.RUnlock()
+	return len(fake.editCommentArgsForCall)
+}
+
+func (fake *FakeIssues) EditCommentCalls(stub func(context.Context, string, string, int64, *github.IssueComment) (*github.IssueComment, *github.Response, error)) {
+	fake.editCommentMutex.Lock()
+	defer fake.editCommentMutex.Unlock()
+	fake.EditCommentStub = stub
+}
+
+func (fake *FakeIssues) EditCommentArgsForCall(i int) (context.Context, string, string, int64, *github.IssueComment) {
+	fake.editCommentMutex.RLock()
+	defer fake.editCommentMutex.RUnlock()
+	argsForCall := fake.editCommentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeIssues) EditCommentReturns(result1 *github.IssueComment, result2 *github.Response, result3 error) {
+	fake.editCommentMutex.Lock()
+	defer fake.editCommentMutex.Unlock()
+	fake.EditCommentStub = nil
+	fake.editCommentReturns = struct {
+		result1 *github.IssueComment
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeIssues) EditCommentReturnsOnCall(i int, result1 *github.IssueComment, result2 *github.Response, result3 error) {
+	fake.editCommentMutex.Lock()
+	defer fake.editCommentMutex.Unlock()
+	fake.EditCommentStub = nil
+	if fake.editCommentReturnsOnCall == nil {
+		fake.editCommentReturnsOnCall = make(map[int]struct {
+			result1 *github.IssueComment
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.editCommentReturnsOnCall[i] = struct {
+		result1 *github.IssueComment
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeIssues) ListByRepo(arg1 context.Context, arg2 string, arg3 string, arg4 *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
 	fake.listByRepoMutex.Lock()
 	ret, specificReturn := fake.listByRepoReturnsOnCall[len(fake.listByRepoArgsForCall)]
@@ -197,13 +396,90 @@ func (fake *FakeIssues) ListByRepoReturnsOnCall(i int, result1 []*github.Issue,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeIssues) ListComments(arg1 context.Context, arg2 string, arg3 string, arg4 int, arg5 *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	fake.listCommentsMutex.Lock()
+	ret, specificReturn := fake.listCommentsReturnsOnCall[len(fake.listCommentsArgsForCall)]
+	fake.listCommentsArgsForCall = append(fake.listCommentsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 *github.IssueListCommentsOptions
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.ListCommentsStub
+	fakeReturns := fake.listCommentsReturns
+	fake.recordInvocation("ListComments", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.listCommentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeIssues) ListCommentsCallCount() int {
+	fake.listCommentsMutex.RLock()
+	defer fake.listCommentsMutex.RUnlock()
+	return len(fake.listCommentsArgsForCall)
+}
+
+func (fake *FakeIssues) ListCommentsCalls(stub func(context.Context, string, string, int, *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error)) {
+	fake.listCommentsMutex.Lock()
+	defer fake.listCommentsMutex.Unlock()
+	fake.ListCommentsStub = stub
+}
+
+func (fake *FakeIssues) ListCommentsArgsForCall(i int) (context.Context, string, string, int, *github.IssueListCommentsOptions) {
+	fake.listCommentsMutex.RLock()
+	defer fake.listCommentsMutex.RUnlock()
+	argsForCall := fake.listCommentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeIssues) ListCommentsReturns(result1 []*github.IssueComment, result2 *github.Response, result3 error) {
+	fake.listCommentsMutex.Lock()
+	defer fake.listCommentsMutex.Unlock()
+	fake.ListCommentsStub = nil
+	fake.listCommentsReturns = struct {
+		result1 []*github.IssueComment
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeIssues) ListCommentsReturnsOnCall(i int, result1 []*github.IssueComment, result2 *github.Response, result3 error) {
+	fake.listCommentsMutex.Lock()
+	defer fake.listCommentsMutex.Unlock()
+	fake.ListCommentsStub = nil
+	if fake.listCommentsReturnsOnCall == nil {
+		fake.listCommentsReturnsOnCall = make(map[int]struct {
+			result1 []*github.IssueComment
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.listCommentsReturnsOnCall[i] = struct {
+		result1 []*github.IssueComment
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeIssues) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.addLabelsToIssueMutex.RLock()
 	defer fake.addLabelsToIssueMutex.RUnlock()
+	fake.createCommentMutex.RLock()
+	defer fake.createCommentMutex.RUnlock()
+	fake.editCommentMutex.RLock()
+	defer fake.editCommentMutex.RUnlock()
 	fake.listByRepoMutex.RLock()
 	defer fake.listByRepoMutex.RUnlock()
+	fake.listCommentsMutex.RLock()
+	defer fake.listCommentsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
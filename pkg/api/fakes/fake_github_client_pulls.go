@@ -28,6 +28,25 @@ type FakePullRequests struct {
 		result2 *github.Response
 		result3 error
 	}
+	EditStub        func(context.Context, string, string, int, *github.PullRequest) (*github.PullRequest, *github.Response, error)
+	editMutex       sync.RWMutex
+	editArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 *github.PullRequest
+	}
+	editReturns struct {
+		result1 *github.PullRequest
+		result2 *github.Response
+		result3 error
+	}
+	editReturnsOnCall map[int]struct {
+		result1 *github.PullRequest
+		result2 *github.Response
+		result3 error
+	}
 	GetStub        func(context.Context, string, string, int) (*github.PullRequest, *github.Response, error)
 	getMutex       sync.RWMutex
 	getArgsForCall []struct {
@@ -46,6 +65,43 @@ type FakePullRequests struct {
 		result2 *github.Response
 		result3 error
 	}
+	ListStub        func(context.Context, string, string, *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error)
+	listMutex       sync.RWMutex
+	listArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *github.PullRequestListOptions
+	}
+	listReturns struct {
+		result1 []*github.PullRequest
+		result2 *github.Response
+		result3 error
+	}
+	listReturnsOnCall map[int]struct {
+		result1 []*github.PullRequest
+		result2 *github.Response
+		result3 error
+	}
+	ListCommitsStub        func(context.Context, string, string, int, *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error)
+	listCommitsMutex       sync.RWMutex
+	listCommitsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 *github.ListOptions
+	}
+	listCommitsReturns struct {
+		result1 []*github.RepositoryCommit
+		result2 *github.Response
+		result3 error
+	}
+	listCommitsReturnsOnCall map[int]struct {
+		result1 []*github.RepositoryCommit
+		result2 *github.Response
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -120,6 +176,77 @@ func (fake *FakePullRequests) CreateReturnsOnCall(i int, result1 *github.PullReq
 	}{result1, result2, result3}
 }
 
+func (fake *FakePullRequests) Edit(arg1 context.Context, arg2 string, arg3 string, arg4 int, arg5 *github.PullRequest) (*github.PullRequest, *github.Response, error) {
+	fake.editMutex.Lock()
+	ret, specificReturn := fake.editReturnsOnCall[len(fake.editArgsForCall)]
+	fake.editArgsForCall = append(fake.editArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 *github.PullRequest
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.EditStub
+	fakeReturns := fake.editReturns
+	fake.recordInvocation("Edit", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.editMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePullRequests) EditCallCount() int {
+	fake.editMutex.RLock()
+	defer fake.editMutex.RUnlock()
+	return len(fake.editArgsForCall)
+}
+
+func (fake *FakePullRequests) EditCalls(stub func(context.Context, string, string, int, *github.PullRequest) (*github.PullRequest, *github.Response, error)) {
+	fake.editMutex.Lock()
+	defer fake.editMutex.Unlock()
+	fake.EditStub = stub
+}
+
+func (fake *FakePullRequests) EditArgsForCall(i int) (context.Context, string, string, int, *github.PullRequest) {
+	fake.editMutex.RLock()
+	defer fake.editMutex.RUnlock()
+	argsForCall := fake.editArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakePullRequests) EditReturns(result1 *github.PullRequest, result2 *github.Response, result3 error) {
+	fake.editMutex.Lock()
+	defer fake.editMutex.Unlock()
+	fake.EditStub = nil
+	fake.editReturns = struct {
+		result1 *github.PullRequest
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePullRequests) EditReturnsOnCall(i int, result1 *github.PullRequest, result2 *github.Response, result3 error) {
+	fake.editMutex.Lock()
+	defer fake.editMutex.Unlock()
+	fake.EditStub = nil
+	if fake.editReturnsOnCall == nil {
+		fake.editReturnsOnCall = make(map[int]struct {
+			result1 *github.PullRequest
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.editReturnsOnCall[i] = struct {
+		result1 *github.PullRequest
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePullRequests) Get(arg1 context.Context, arg2 string, arg3 string, arg4 int) (*github.PullRequest, *github.Response, error) {
 	fake.getMutex.Lock()
 	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
@@ -190,13 +317,160 @@ func (fake *FakePullRequests) GetReturnsOnCall(i int, result1 *github.PullReques
 	}{result1, result2, result3}
 }
 
+func (fake *FakePullRequests) List(arg1 context.Context, arg2 string, arg3 string, arg4 *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+	fake.listMutex.Lock()
+	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
+	fake.listArgsForCall = append(fake.listArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *github.PullRequestListOptions
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ListStub
+	fakeReturns := fake.listReturns
+	fake.recordInvocation("List", []interface{}{arg1, arg2, arg3, arg4})
+	fake.listMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePullRequests) ListCallCount() int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return len(fake.listArgsForCall)
+}
+
+func (fake *FakePullRequests) ListCalls(stub func(context.Context, string, string, *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error)) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = stub
+}
+
+func (fake *FakePullRequests) ListArgsForCall(i int) (context.Context, string, string, *github.PullRequestListOptions) {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	argsForCall := fake.listArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePullRequests) ListReturns(result1 []*github.PullRequest, result2 *github.Response, result3 error) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = nil
+	fake.listReturns = struct {
+		result1 []*github.PullRequest
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePullRequests) ListReturnsOnCall(i int, result1 []*github.PullRequest, result2 *github.Response, result3 error) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = nil
+	if fake.listReturnsOnCall == nil {
+		fake.listReturnsOnCall = make(map[int]struct {
+			result1 []*github.PullRequest
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.listReturnsOnCall[i] = struct {
+		result1 []*github.PullRequest
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePullRequests) ListCommits(arg1 context.Context, arg2 string, arg3 string, arg4 int, arg5 *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+	fake.listCommitsMutex.Lock()
+	ret, specificReturn := fake.listCommitsReturnsOnCall[len(fake.listCommitsArgsForCall)]
+	fake.listCommitsArgsForCall = append(fake.listCommitsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 *github.ListOptions
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.ListCommitsStub
+	fakeReturns := fake.listCommitsReturns
+	fake.recordInvocation("ListCommits", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.listCommitsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePullRequests) ListCommitsCallCount() int {
+	fake.listCommitsMutex.RLock()
+	defer fake.listCommitsMutex.RUnlock()
+	return len(fake.listCommitsArgsForCall)
+}
+
+func (fake *FakePullRequests) ListCommitsCalls(stub func(context.Context, string, string, int, *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error)) {
+	fake.listCommitsMutex.Lock()
+	defer fake.listCommitsMutex.Unlock()
+	fake.ListCommitsStub = stub
+}
+
+func (fake *FakePullRequests) ListCommitsArgsForCall(i int) (context.Context, string, string, int, *github.ListOptions) {
+	fake.listCommitsMutex.RLock()
+	defer fake.listCommitsMutex.RUnlock()
+	argsForCall := fake.listCommitsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakePullRequests) ListCommitsReturns(result1 []*github.RepositoryCommit, result2 *github.Response, result3 error) {
+	fake.listCommitsMutex.Lock()
+	defer fake.listCommitsMutex.Unlock()
+	fake.ListCommitsStub = nil
+	fake.listCommitsReturns = struct {
+		result1 []*github.RepositoryCommit
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePullRequests) ListCommitsReturnsOnCall(i int, result1 []*github.RepositoryCommit, result2 *github.Response, result3 error) {
+	fake.listCommitsMutex.Lock()
+	defer fake.listCommitsMutex.Unlock()
+	fake.ListCommitsStub = nil
+	if fake.listCommitsReturnsOnCall == nil {
+		fake.listCommitsReturnsOnCall = make(map[int]struct {
+			result1 []*github.RepositoryCommit
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.listCommitsReturnsOnCall[i] = struct {
+		result1 []*github.RepositoryCommit
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePullRequests) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
+	fake.editMutex.RLock()
+	defer fake.editMutex.RUnlock()
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	fake.listCommitsMutex.RLock()
+	defer fake.listCommitsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -25,6 +25,26 @@ type PullRequests interface {
 		repo string,
 		number int,
 	) (*github.PullRequest, *github.Response, error)
+	List(
+		ctx context.Context,
+		owner string,
+		repo string,
+		opts *github.PullRequestListOptions,
+	) ([]*github.PullRequest, *github.Response, error)
+	Edit(
+		ctx context.Context,
+		owner string,
+		repo string,
+		number int,
+		pull *github.PullRequest,
+	) (*github.PullRequest, *github.Response, error)
+	ListCommits(
+		ctx context.Context,
+		owner string,
+		repo string,
+		number int,
+		opts *github.ListOptions,
+	) ([]*github.RepositoryCommit, *github.Response, error)
 }
 
 // Issues defines the GitHub client's issues service.
@@ -44,6 +64,27 @@ type Issues interface {
 		repo string,
 		opts *github.IssueListByRepoOptions,
 	) ([]*github.Issue, *github.Response, error)
+	CreateComment(
+		ctx context.Context,
+		owner string,
+		repo string,
+		number int,
+		comment *github.IssueComment,
+	) (*github.IssueComment, *github.Response, error)
+	EditComment(
+		ctx context.Context,
+		owner string,
+		repo string,
+		commentID int64,
+		comment *github.IssueComment,
+	) (*github.IssueComment, *github.Response, error)
+	ListComments(
+		ctx context.Context,
+		owner string,
+		repo string,
+		number int,
+		opts *github.IssueListCommentsOptions,
+	) ([]*github.IssueComment, *github.Response, error)
 }
 
 // Git defines the GitHub client's git service.
@@ -82,6 +123,24 @@ type Git interface {
 		ref *github.Reference,
 		force bool,
 	) (*github.Reference, *github.Response, error)
+	CreateBlob(
+		ctx context.Context,
+		owner string,
+		repo string,
+		blob *github.Blob,
+	) (*github.Blob, *github.Response, error)
+	DeleteRef(
+		ctx context.Context,
+		owner string,
+		repo string,
+		ref string,
+	) (*github.Response, error)
+	ListMatchingRefs(
+		ctx context.Context,
+		owner string,
+		repo string,
+		opts *github.ReferenceListOptions,
+	) ([]*github.Reference, *github.Response, error)
 }
 
 // Repositories defines the GitHub client's repositories service.
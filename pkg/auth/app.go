@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// DefaultBaseURL is the URL of the GitHub API.
+	DefaultBaseURL = "https://api.github.com/"
+	// jwtLifetime is how long the JWT of the app is valid. GitHub allows at most 10 minutes.
+	jwtLifetime = 9 * time.Minute
+	// clockSkew is subtracted from the issue time of the JWT in case the clocks of GitHub and the runner differ.
+	clockSkew = time.Minute
+)
+
+// AppTokenSource mints installation tokens of a GitHub App. Use NewAppTokenSource to get a source which
+// reuses a token until it expires.
+type AppTokenSource struct {
+	// AppID is the ID of the GitHub App.
+	AppID int64
+	// InstallationID is the ID of the installation of the app in the organization or user account.
+	InstallationID int64
+	// Key is the private key of the app.
+	Key *rsa.PrivateKey
+	// BaseURL is the URL of the GitHub API, with a trailing slash. Defaults to DefaultBaseURL.
+	BaseURL string
+	// Client is the HTTP client used to request tokens. Defaults to http.DefaultClient.
+	Client *http.Client
+
+	now func() time.Time
+}
+
+// NewAppTokenSource returns a token source which authenticates as the installation of a GitHub App. The
+// private key is the PEM encoded key downloaded from the settings of the app. Tokens are refreshed
+// shortly before they expire. The client is used to request the tokens and may be nil.
+func NewAppTokenSource(
+	appID, installationID int64,
+	privateKey []byte,
+	baseURL string,
+	client *http.Client,
+) (oauth2.TokenSource, error) {
+	key, err := ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &AppTokenSource{
+		AppID:          appID,
+		InstallationID: installationID,
+		Key:            key,
+		BaseURL:        baseURL,
+		Client:         client,
+	}
+
+	return oauth2.ReuseTokenSource(nil, source), nil
+}
+
+// ParsePrivateKey parses a PEM encoded RSA private key in PKCS#1 or PKCS#8 format.
+func ParsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode private key: no PEM data found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("failed to parse private key: not an RSA key")
+	}
+
+	return key, nil
+}
+
+// Token requests a new installation token.
+func (s *AppTokenSource) Token() (*oauth2.Token, error) {
+	jwt, err := s.jwt()
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := s.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	url := fmt.Sprintf("%sapp/installations/%d/access_tokens", baseURL, s.InstallationID)
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+
+	request.Header.Set("Authorization", "Bearer "+jwt)
+	request.Header.Set("Accept", "application/vnd.github+json")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request installation token: %w", err)
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read installation token response: %w", err)
+	}
+
+	if response.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("failed to request installation token: status %d: %s", response.StatusCode, body)
+	}
+
+	var result struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode installation token: %w", err)
+	}
+
+	return &oauth2.Token{
+		AccessToken: result.Token,
+		TokenType:   "token",
+		Expiry:      result.ExpiresAt,
+	}, nil
+}
+
+// jwt creates the JSON Web Token the app authenticates with, signed with RS256.
+func (s *AppTokenSource) jwt() (string, error) {
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+
+	issued := now().Add(-clockSkew)
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JWT header: %w", err)
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		"iat": issued.Unix(),
+		"exp": issued.Add(jwtLifetime).Unix(),
+		"iss": strconv.FormatInt(s.AppID, 10),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JWT claims: %w", err)
+	}
+
+	unsigned := strings.Join([]string{
+		base64.RawURLEncoding.EncodeToString(header),
+		base64.RawURLEncoding.EncodeToString(claims),
+	}, ".")
+
+	digest := sha256.Sum256([]byte(unsigned))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.Key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT: %w", err)
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenServer stands in for the installation token endpoint of GitHub. It verifies the JWT with the
+// public key and returns tokens which expire after the given duration.
+func tokenServer(t *testing.T, key *rsa.PrivateKey, expiresIn time.Duration) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/app/installations/42/access_tokens", r.URL.Path)
+		assert.Equal(t, "application/vnd.github+json", r.Header.Get("Accept"))
+
+		jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		parts := strings.Split(jwt, ".")
+		if !assert.Len(t, parts, 3) {
+			return
+		}
+
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+
+		if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		var claims struct {
+			Iss string `json:"iss"`
+			Iat int64  `json:"iat"`
+			Exp int64  `json:"exp"`
+		}
+
+		payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		assert.NoError(t, json.Unmarshal(payload, &claims))
+		assert.Equal(t, "1234", claims.Iss)
+		assert.LessOrEqual(t, claims.Exp-claims.Iat, int64(10*60))
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": "token-%d", "expires_at": %q}`, n, time.Now().Add(expiresIn).UTC().Format(time.RFC3339))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func generateKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func TestAppTokenSource(t *testing.T) {
+	key, pemKey := generateKey(t)
+	server, calls := tokenServer(t, key, time.Hour)
+
+	source, err := NewAppTokenSource(1234, 42, pemKey, server.URL+"/", nil)
+	require.NoError(t, err)
+
+	token, err := source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+	assert.Equal(t, "token", token.TokenType)
+
+	// the token is reused while it's valid
+	token, err = source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestAppTokenSourceRefreshesExpiringTokens(t *testing.T) {
+	key, pemKey := generateKey(t)
+	// tokens which expire within a few seconds are treated as expired
+	server, calls := tokenServer(t, key, 5*time.Second)
+
+	source, err := NewAppTokenSource(1234, 42, pemKey, server.URL+"/", nil)
+	require.NoError(t, err)
+
+	token, err := source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+
+	token, err = source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token.AccessToken)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestAppTokenSourceWrongKey(t *testing.T) {
+	key, _ := generateKey(t)
+	_, otherKey := generateKey(t)
+	server, _ := tokenServer(t, key, time.Hour)
+
+	source, err := NewAppTokenSource(1234, 42, otherKey, server.URL+"/", nil)
+	require.NoError(t, err)
+
+	_, err = source.Token()
+	assert.ErrorContains(t, err, "failed to request installation token: status 401")
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key, pemKey := generateKey(t)
+
+	parsed, err := ParsePrivateKey(pemKey)
+	require.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	parsed, err = ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+	require.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	_, err = ParsePrivateKey([]byte("not a key"))
+	assert.ErrorContains(t, err, "no PEM data found")
+}
+
+func TestJWTIssuedInThePast(t *testing.T) {
+	key, _ := generateKey(t)
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	source := &AppTokenSource{AppID: 1, Key: key, now: func() time.Time { return now }}
+
+	jwt, err := source.jwt()
+	require.NoError(t, err)
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.Split(jwt, ".")[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"iat": 1672574340, "exp": 1672574880, "iss": "1"}`, string(payload))
+}
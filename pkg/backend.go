@@ -0,0 +1,231 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/forge"
+	"github.com/google/go-github/v43/github"
+)
+
+// Backends which can be used to create and push the commits of a bundle.
+const (
+	// BackendAPI creates the commits through the Git Data API of GitHub, or the API of the configured Forge.
+	BackendAPI = "api"
+	// BackendGit creates the commits in the local repository with the git CLI and pushes them.
+	BackendGit = "git"
+)
+
+// defaultRemote is the remote the git backend pushes to.
+const defaultRemote = "origin"
+
+// committer creates the commits of a bundle on a branch.
+type committer interface {
+	// commit creates the commits on the branch of the existing bundle PR, or on a new branch if
+	// there is none, and returns the name of the branch.
+	commit(existing *github.PullRequest, commits []commitSpec) (string, error)
+}
+
+// committer returns the committer of the configured backend.
+func (n *Bundler) committer() committer {
+	if n.Backend == BackendGit {
+		return &gitCommitter{Bundler: n}
+	}
+
+	if !n.onGitHub() {
+		return &forgeCommitter{Bundler: n}
+	}
+
+	return &apiCommitter{Bundler: n}
+}
+
+// apiCommitter creates the commits using the Git Data API.
+type apiCommitter struct {
+	*Bundler
+}
+
+func (a *apiCommitter) commit(existing *github.PullRequest, commits []commitSpec) (string, error) {
+	branch, ref, err := a.getRef(existing)
+	if err != nil {
+		return "", fmt.Errorf("failed to create ref: %w", err)
+	}
+
+	// An existing bundle branch is reset onto the current base, so the update has to be forced.
+	if err := a.pushCommits(ref, commits, existing != nil); err != nil {
+		return "", err
+	}
+
+	if existing != nil {
+		previous := existing.GetHead().GetSHA()
+		a.undo.add(fmt.Sprintf("reset branch %s to %s", branch, previous), func() error {
+			return a.resetBranch(branch, previous)
+		})
+	}
+
+	return branch, nil
+}
+
+// forgeCommitter creates the commits on a new branch using the API of the Forge.
+type forgeCommitter struct {
+	*Bundler
+}
+
+func (f *forgeCommitter) commit(_ *github.PullRequest, commits []commitSpec) (string, error) {
+	branch := f.generateCommitBranch()
+
+	if err := f.forge().CreateBranch(context.Background(), branch, f.TargetBranch); err != nil {
+		return "", fmt.Errorf("failed to create branch: %w", err)
+	}
+
+	for _, c := range commits {
+		commit := forge.Commit{
+			Branch:      branch,
+			Message:     c.message,
+			AuthorName:  f.AuthorName,
+			AuthorEmail: f.AuthorEmail,
+		}
+
+		for path, state := range c.files {
+			commit.Files = append(commit.Files, forge.File{
+				Path:       path,
+				Content:    state.content,
+				Executable: state.mode == modeExecutable,
+				Added:      state.added,
+				Deleted:    state.deleted,
+				Symlink:    state.mode == modeSymlink,
+			})
+		}
+
+		// keep the requests reproducible
+		sort.Slice(commit.Files, func(i, j int) bool {
+			return commit.Files[i].Path < commit.Files[j].Path
+		})
+
+		if err := f.forge().CommitFiles(context.Background(), commit); err != nil {
+			return "", fmt.Errorf("failed to commit files: %w", err)
+		}
+	}
+
+	return branch, nil
+}
+
+// gitCommitter creates the commits in the local repository using the git CLI and pushes them.
+type gitCommitter struct {
+	*Bundler
+}
+
+func (g *gitCommitter) commit(existing *github.PullRequest, commits []commitSpec) (string, error) {
+	branch := g.generateCommitBranch()
+	if existing != nil {
+		branch = existing.GetHead().GetRef()
+	}
+
+	// The commits are created on the detached HEAD of the worktree and pushed from there. A local branch
+	// would be shared with the checkout of the user.
+	for _, c := range commits {
+		if err := g.commitFiles(c); err != nil {
+			return "", err
+		}
+	}
+
+	args := []string{"push", defaultRemote, "HEAD:refs/heads/" + branch}
+	if existing != nil {
+		args = []string{"push", "--force", defaultRemote, args[2]}
+	}
+
+	if err := g.git(args...); err != nil {
+		return "", err
+	}
+
+	if existing != nil {
+		previous := existing.GetHead().GetSHA()
+		g.undo.add(fmt.Sprintf("reset branch %s to %s", branch, previous), func() error {
+			return g.runGit(".", "push", "--force", defaultRemote, previous+":refs/heads/"+branch)
+		})
+	} else {
+		g.undo.add("delete branch "+branch, func() error {
+			return g.runGit(".", "push", "--delete", defaultRemote, branch)
+		})
+	}
+
+	return branch, nil
+}
+
+// commitFiles writes the state of the files into the working tree, stages and commits them.
+func (g *gitCommitter) commitFiles(c commitSpec) error {
+	paths := make([]string, 0, len(c.files))
+
+	for file, state := range c.files {
+		if err := writeFile(file, state); err != nil {
+			return err
+		}
+
+		paths = append(paths, file)
+	}
+
+	args := []string{
+		"-c", "user.name=" + g.AuthorName,
+		"-c", "user.email=" + g.AuthorEmail,
+		"commit", "-m", c.message,
+	}
+
+	if len(paths) == 0 {
+		// An update which didn't change anything still gets its commit, like with the API. Without paths,
+		// git add would stage the whole worktree.
+		args = append(args, "--allow-empty")
+	} else {
+		// -A also stages the deletion of files
+		if err := g.git(append([]string{"add", "-A", "--"}, paths...)...); err != nil {
+			return err
+		}
+	}
+
+	if g.GitSigningKey != "" {
+		args = append(args, "--gpg-sign="+g.GitSigningKey)
+	}
+
+	return g.git(args...)
+}
+
+// git runs a git command in the current working directory, which is the worktree of the run.
+func (g *gitCommitter) git(args ...string) error {
+	return g.runGit(".", args...)
+}
+
+// writeFile puts the file into the given state on disk.
+func writeFile(path string, state fileState) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove file: %w", err)
+	}
+
+	if state.deleted {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create folder: %w", err)
+	}
+
+	if state.mode == modeSymlink {
+		if err := os.Symlink(string(state.content), path); err != nil {
+			return fmt.Errorf("failed to create link: %w", err)
+		}
+
+		return nil
+	}
+
+	var perm os.FileMode = 0o644
+	if state.mode == modeExecutable {
+		perm = 0o755
+	}
+
+	if err := os.WriteFile(path, state.content, perm); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
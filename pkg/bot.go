@@ -0,0 +1,42 @@
+package pkg
+
+import (
+	"fmt"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/metadata"
+)
+
+// Bot is an account whose pull requests are bundled.
+type Bot struct {
+	// Name is the login of the bot, for example app/dependabot.
+	Name string `yaml:"name"`
+	// Parser is the name of the parser for the pull requests of the bot, dependabot or renovate. If empty,
+	// it's picked by the branch of each pull request.
+	Parser string `yaml:"parser"`
+	// ID is the ID of the bot account on GitHub, which is verified with VerifyBots. It's known for Dependabot
+	// and Renovate.
+	ID int64 `yaml:"id"`
+}
+
+// Validate checks the name and the parser of the bot.
+func (b Bot) Validate() error {
+	if b.Name == "" {
+		return fmt.Errorf("bot name must not be empty")
+	}
+
+	if _, ok := metadata.LookupParser(b.Parser); b.Parser != "" && !ok {
+		return fmt.Errorf("unknown parser %s of bot %s, must be %s or %s",
+			b.Parser, b.Name, metadata.ParserDependabot, metadata.ParserRenovate)
+	}
+
+	return nil
+}
+
+// bots returns the bots whose pull requests are bundled. Without Bots, that's BotName.
+func (n *Bundler) bots() []Bot {
+	if len(n.Bots) > 0 {
+		return n.Bots
+	}
+
+	return []Bot{{Name: n.BotName}}
+}
@@ -0,0 +1,76 @@
+package pkg_test
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v43/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Skarlso/dependabot-bundler/pkg"
+)
+
+func TestBundlerBots(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.Bots = []pkg.Bot{
+		{Name: "app/dependabot"},
+		{Name: "internal-bot", Parser: "renovate"},
+	}
+	dependabotPR := &github.Issue{
+		Number:           github.Int(1),
+		Body:             github.String("Bumps [github.com/test/test](github.com/test/test) from 1.0.0 to 1.1.0."),
+		PullRequestLinks: &github.PullRequestLinks{},
+	}
+	f.issues.ListByRepoReturnsOnCall(0, []*github.Issue{dependabotPR}, &github.Response{}, nil)
+	// the internal bot re-opened the PR of Dependabot, it's only bundled once
+	f.issues.ListByRepoReturnsOnCall(1, []*github.Issue{
+		dependabotPR,
+		{
+			Number: github.Int(2),
+			Body: github.String("| Package | Type | Update | Change | Package file |\n|---|---|---|---|---|\n" +
+				"| golang.org/x/sys | require | minor | `v0.14.0` -> `v0.15.0` | `go.mod` |\n"),
+			PullRequestLinks: &github.PullRequestLinks{},
+		},
+	}, &github.Response{}, nil)
+	f.pulls.GetReturnsOnCall(0, &github.PullRequest{
+		Title: github.String("Bump github.com/test/test from 1.0.0 to 1.1.0"),
+		Head:  &github.PullRequestBranch{Ref: github.String("dependabot/go_modules/github.com/test/test-1.1.0")},
+	}, nil, nil)
+	f.pulls.GetReturnsOnCall(1, &github.PullRequest{
+		Title: github.String("Bump github.com/test/test from 1.0.0 to 1.1.0"),
+		Head:  &github.PullRequestBranch{Ref: github.String("dependabot/go_modules/github.com/test/test-1.1.0")},
+	}, nil, nil)
+	f.pulls.GetReturnsOnCall(2, &github.PullRequest{
+		Title: github.String("Update golang.org/x/sys"),
+		Head:  &github.PullRequestBranch{Ref: github.String("updates/golang.org-x-sys")},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	require.Equal(t, 2, f.issues.ListByRepoCallCount())
+	_, _, _, opts := f.issues.ListByRepoArgsForCall(0)
+	assert.Equal(t, "app/dependabot", opts.Creator)
+	_, _, _, opts = f.issues.ListByRepoArgsForCall(1)
+	assert.Equal(t, "internal-bot", opts.Creator)
+
+	require.Equal(t, 2, f.updater.UpdateCallCount())
+	_, branch, _ := f.updater.UpdateArgsForCall(0)
+	assert.Equal(t, "dependabot/go_modules/github.com/test/test-1.1.0", branch)
+	body, branch, _ := f.updater.UpdateArgsForCall(1)
+	assert.Equal(t, "Bumps [golang.org/x/sys](golang.org/x/sys) from 0.14.0 to 0.15.0.", body)
+	assert.Equal(t, "dependabot/go_modules/golang.org/x/sys-0.15.0", branch)
+
+	_, _, _, pr := f.pulls.CreateArgsForCall(0)
+	assert.Contains(t, pr.GetBody(), "<!-- dependabot-bundler-prs: 1,2 -->")
+}
+
+func TestBundlerInvalidBots(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.Bots = []pkg.Bot{{Name: "app/dependabot", Parser: "other"}}
+
+	assert.ErrorContains(t, bundler.Bundle(), "unknown parser other of bot app/dependabot")
+	assert.Equal(t, 0, f.issues.ListByRepoCallCount())
+
+	bundler.Bots = []pkg.Bot{{Parser: "renovate"}}
+	assert.ErrorContains(t, bundler.Bundle(), "bot name must not be empty")
+}
@@ -3,21 +3,36 @@ package pkg
 import (
 	"context"
 	"fmt"
-	"io"
-	"os"
+	"strings"
 	"time"
 
 	"github.com/Skarlso/dependabot-bundler/pkg/api"
+	"github.com/Skarlso/dependabot-bundler/pkg/forge"
 	"github.com/Skarlso/dependabot-bundler/pkg/logger"
+	"github.com/Skarlso/dependabot-bundler/pkg/metadata"
 	"github.com/Skarlso/dependabot-bundler/pkg/providers"
 	"github.com/google/go-github/v43/github"
 )
 
-const defaultNumberOfItemsPerPage = 100
+const (
+	defaultNumberOfItemsPerPage = 100
+	// bundleMarker is a hidden marker placed into the body of every PR the bundler opens.
+	// It is used to find a previously opened bundle PR which can be updated instead of opening a new one.
+	bundleMarker = "<!-- dependabot-bundler -->"
+	// branchPrefix is the prefix of the branches the bundler creates. It is followed by a unix timestamp.
+	branchPrefix = "bundler-"
+)
 
 // Bundler bundles.
 type Bundler struct {
 	Config
+
+	// undo records the side effects of the current run.
+	undo *rollback
+	// group is the name of the group which is bundled, empty without groups.
+	group string
+	// branchPrefix overrides the prefix of the created branches.
+	branchPrefix string
 }
 
 // Config contains dependencies and configuration for the Bundler.
@@ -26,11 +41,66 @@ type Config struct {
 	TargetBranch string
 	Owner        string
 	Repo         string
-	BotName      string
-	AuthorName   string
-	AuthorEmail  string
-	PRTitle      string
-	Logger       logger.Logger
+	// BotName is the login of the bot whose pull requests are bundled. It's ignored if Bots is set.
+	BotName     string
+	AuthorName  string
+	AuthorEmail string
+	PRTitle     string
+	// CloseSuperseded defines if and when the bundled PRs are closed. One of CloseNever, CloseOnOpen
+	// or CloseOnMerge.
+	CloseSuperseded string
+	// CommentOnPRs leaves a comment with a link to the bundle on each bundled PR.
+	CommentOnPRs bool
+	// PRTitleTemplate, PRBodyTemplate and CommitMessageTemplate are text/template sources which are
+	// rendered with TemplateData. If empty, the defaults are used.
+	PRTitleTemplate       string
+	PRBodyTemplate        string
+	CommitMessageTemplate string
+	// CommitPerDependency creates a separate commit for each bundled update instead of a single one.
+	CommitPerDependency bool
+	// BlobThreshold is the size in bytes above which files are uploaded through the blobs API instead
+	// of being inlined into the tree. Defaults to DefaultBlobThreshold.
+	BlobThreshold int
+	// Backend defines how the commits are created and pushed. Either BackendAPI or BackendGit.
+	// Defaults to BackendAPI.
+	Backend string
+	// GitSigningKey is the ID of the GPG key the git backend signs the commits with. The key has
+	// to be available to the git CLI. If empty, the commits are not signed.
+	GitSigningKey string
+	// KeepOnFailure keeps the created branches, PRs and the worktree if a run fails, for debugging.
+	// By default, they are rolled back.
+	KeepOnFailure bool
+	// Filter selects which pull requests are bundled.
+	Filter Filter
+	// MaxUpdateLevel is the highest semantic version level which is bundled: patch, minor or major.
+	// Empty means no limit. Excluded updates are listed separately in the description.
+	MaxUpdateLevel string
+	// ExcludeMajor leaves major updates out of the bundle. It's a shortcut for a MaxUpdateLevel of minor.
+	ExcludeMajor bool
+	// Declared lists the ecosystem and directory pairs of dependabot.yml. If set, PRs of other pairs are skipped.
+	Declared []Declaration
+	// Bots lists the bots whose pull requests are bundled together. A pull request is only bundled once,
+	// even if it's listed for several of them.
+	Bots []Bot
+	// VerifyBots skips pull requests which weren't opened by one of the bots from a branch of the repository
+	// itself. On GitHub, the author also has to be a bot account with the ID of the bot.
+	VerifyBots bool
+	// RequireVerifiedCommits skips pull requests with commits which don't carry a verified signature. GitHub only.
+	RequireVerifiedCommits bool
+	// Enterprise is set when the API is the one of a GitHub Enterprise Server. The IDs of the bot accounts of
+	// github.com don't apply there, so only the IDs configured for the bots are checked.
+	Enterprise bool
+	// Groups splits the updates into several bundles. If empty, a single bundle is created with the
+	// rules above.
+	Groups []Group
+	// BundleUngrouped bundles the updates which none of the Groups took into one more bundle, with the
+	// rules above. It's the same bundle as without groups.
+	BundleUngrouped bool
+	// Forge is the platform the bundle is opened on. If nil or a forge.GitHub, the GitHub API clients below
+	// are used for everything besides the change requests. Updating an existing bundle, closing and commenting
+	// on the bundled PRs and the rollback of created branches and PRs are only supported on GitHub.
+	Forge  forge.Forge
+	Logger logger.Logger
 
 	Issues       api.Issues
 	Pulls        api.PullRequests
@@ -48,88 +118,259 @@ func NewBundler(cfg Config) *Bundler {
 	}
 }
 
-// Bundle performs the action which bundles together dependabot PRs.
-func (n *Bundler) Bundle() error {
+// candidate is a pull request which could be included in the bundle.
+type candidate struct {
+	body   string
+	branch string
+	title  string
+	labels []string
+	update metadata.Update
+	// parser is the name of the parser of the update information. The updaters only understand the
+	// pull requests of Dependabot as they are.
+	parser string
+}
+
+// newCandidate creates a candidate and parses the update information out of the change request with the
+// named parser. If the name is empty, the parser is picked by the branch.
+func newCandidate(request *forge.ChangeRequest, parser string) candidate {
+	if parser == "" {
+		parser = metadata.DetectParser(request.SourceBranch)
+	}
+
+	parse, _ := metadata.LookupParser(parser)
+
+	return candidate{
+		body:   request.Body,
+		branch: request.SourceBranch,
+		title:  request.Title,
+		labels: request.Labels,
+		update: parse(request.Number, request.URL, request.Title, request.Body, request.SourceBranch),
+		parser: parser,
+	}
+}
+
+// updaterInput returns the body, branch and title which are passed to the updater.
+func (c candidate) updaterInput() (string, string, string) {
+	if c.parser == metadata.ParserDependabot {
+		return c.body, c.branch, c.title
+	}
+
+	title, body, branch := c.update.AsDependabot()
+
+	return body, branch, title
+}
+
+// complete returns whether the updater can be given the update. The PRs of Dependabot are passed on as they
+// are, the ones of other bots are described by the parsed update, which needs the dependency, both versions and
+// the directory.
+func (c candidate) complete() bool {
+	if c.parser == metadata.ParserDependabot {
+		return true
+	}
+
+	return c.update.Dependency != "" && c.update.From != "" && c.update.To != "" && c.update.Directory != ""
+}
+
+// forge returns the configured Forge, or one which uses the GitHub API clients.
+func (n *Bundler) forge() forge.Forge {
+	if n.Forge != nil {
+		return n.Forge
+	}
+
+	return &forge.GitHub{
+		Owner:  n.Owner,
+		Repo:   n.Repo,
+		Issues: n.Issues,
+		Pulls:  n.Pulls,
+		Logger: n.Logger,
+	}
+}
+
+// onGitHub returns whether the bundle is opened on GitHub, where the GitHub API clients are used for
+// everything besides the change requests.
+func (c Config) onGitHub() bool {
+	if c.Forge == nil {
+		return true
+	}
+
+	_, ok := c.Forge.(*forge.GitHub)
+
+	return ok
+}
+
+// Bundle performs the action which bundles together dependabot PRs. If groups are configured, a PR is
+// opened or updated for each of them.
+// If it fails halfway, the side effects of the run are rolled back unless KeepOnFailure is set.
+func (n *Bundler) Bundle() (err error) {
 	n.Logger.Log("attempting to bundle PRs\n")
 
-	issues, response, err := n.Issues.ListByRepo(context.Background(), n.Owner, n.Repo, &github.IssueListByRepoOptions{
-		State:   "open",
-		Creator: n.BotName,
-		ListOptions: github.ListOptions{
-			PerPage: defaultNumberOfItemsPerPage,
-		},
-	})
+	n.undo = &rollback{}
+
+	if err := n.validate(); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		if n.KeepOnFailure {
+			n.Logger.Log("run failed, keeping created branches and PRs\n")
+
+			return
+		}
+
+		n.runRollback()
+	}()
+
+	if n.CloseSuperseded == CloseOnMerge {
+		if err := n.closeMergedBundles(); err != nil {
+			n.Logger.Log("failed to close PRs of merged bundles: %s\n", err)
+		}
+	}
+
+	open, err := n.listCandidates()
+	if err != nil {
+		return err
+	}
+
+	claimed := make(map[int]struct{})
+
+	if len(n.Groups) == 0 {
+		return n.bundle(open, claimed)
+	}
+
+	for _, group := range n.Groups {
+		n.Logger.Log("bundling group %s\n", group.Name)
+
+		bundler := n.forGroup(group)
+		if err := bundler.bundle(open, claimed); err != nil {
+			// the bundles of the previous groups are complete, only the failed one is rolled back
+			n.undo = bundler.undo
+
+			return fmt.Errorf("failed to bundle group %s: %w", group.Name, err)
+		}
+	}
+
+	if n.BundleUngrouped {
+		n.Logger.Log("bundling the updates of no group\n")
+
+		return n.bundle(open, claimed)
+	}
+
+	return nil
+}
+
+// bundle opens or updates a single bundle PR with the candidates which match the rules of the bundler.
+// The numbers of the bundled candidates are added to claimed.
+func (n *Bundler) bundle(open []candidate, claimed map[int]struct{}) (err error) {
+	existing, err := n.findExistingPR()
 	if err != nil {
-		return n.logErrorWithBody(err, response.Body)
+		n.Logger.Log("failed to look for existing bundle PR\n")
+
+		return fmt.Errorf("failed to find existing pr: %w", err)
 	}
 
+	candidates := n.filterCandidates(unclaimed(n.carryOver(existing, open), claimed))
+	candidates, excluded := n.applyLevelPolicy(candidates)
+
+	for _, c := range candidates {
+		claimed[c.update.Number] = struct{}{}
+	}
+
+	if len(candidates) == 0 {
+		n.Logger.Log("no pull requests found to bundle, exiting...")
+
+		return nil
+	}
+
+	// The updates are applied in a separate worktree, so the checkout of the user is never modified.
+	removeWorktree, err := n.createWorktree()
+	if err != nil {
+		n.Logger.Log("failed to create worktree\n")
+
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+
+	defer func() {
+		removeWorktree(err != nil && n.KeepOnFailure)
+	}()
+
 	var (
-		count         int
-		prNumbers     string
-		modifiedFiles = make(map[string]struct{}) // used for deduplication
+		included []metadata.Update
+		changes  []change
 	)
 
-	for _, issue := range issues {
-		if issue.PullRequestLinks != nil {
-			pr, _, err := n.Pulls.Get(context.Background(), n.Owner, n.Repo, issue.GetNumber())
-			if err != nil {
-				n.Logger.Debug("failed to get pull request for number %d with error %s, skipping \n", issue.GetNumber(), err)
-
-				continue
-			}
-			// The head ref is something like this:
-			// dependabot/github_actions/actions/github-script-6.0.0
-			// dependabot/go_modules/github.com/aws/aws-sdk-go-v2/service/ssm-1.27.0
-			// Which we can use to detect what kind of update we would like to perform.
-			files, err := n.Updater.Update(issue.GetBody(), pr.GetHead().GetRef(), pr.GetTitle())
-			if err != nil {
-				n.Logger.Debug("failed to update %s issue; failure was: %s, skipping...\n", issue.GetTitle(), err)
+	for _, c := range candidates {
+		// The head ref is something like this:
+		// dependabot/github_actions/actions/github-script-6.0.0
+		// dependabot/go_modules/github.com/aws/aws-sdk-go-v2/service/ssm-1.27.0
+		// Which we can use to detect what kind of update we would like to perform. Updates of Renovate
+		// are described the same way.
+		files, err := n.Updater.Update(c.updaterInput())
+		if err != nil {
+			n.Logger.Debug("failed to update %s issue; failure was: %s, skipping...\n", c.title, err)
 
-				continue
-			}
+			continue
+		}
 
-			for _, f := range files {
-				modifiedFiles[f] = struct{}{}
-			}
-			count++
+		// Updates can modify the same files, so their content is recorded right after each update.
+		contents, err := readFiles(files)
+		if err != nil {
+			n.Logger.Log("failed to read modified files\n")
 
-			prNumbers += fmt.Sprintf("#%d\n", *issue.Number)
+			return fmt.Errorf("failed to read modified files of %s: %w", c.title, err)
 		}
+
+		included = append(included, c.update)
+		changes = append(changes, change{title: c.title, update: c.update, files: contents})
 	}
 
+	count := len(included)
 	if count == 0 {
 		n.Logger.Log("no pull requests found to bundle, exiting...")
 
 		return nil
 	}
 
-	n.Logger.Log("gathered %d pull requests, opening PR...\n", count)
-	// open a PR with the modifications
-	branch, ref, err := n.getRef()
+	msgs, err := n.renderMessages(included, excluded)
 	if err != nil {
-		n.Logger.Log("failed to create ref\n")
+		n.Logger.Log("failed to render templates\n")
 
-		return fmt.Errorf("failed to create ref: %w", err)
+		return fmt.Errorf("failed to render templates: %w", err)
 	}
 
-	tree, err := n.getTree(modifiedFiles, ref)
-	if err != nil {
-		n.Logger.Log("failed to get tree\n")
-
-		return fmt.Errorf("failed to get tree: %w", err)
+	if existing != nil {
+		n.Logger.Log("gathered %d pull requests, updating PR #%d...\n", count, existing.GetNumber())
+	} else {
+		n.Logger.Log("gathered %d pull requests, opening PR...\n", count)
 	}
 
-	if err := n.pushCommit(ref, tree); err != nil {
+	// open a PR with the modifications
+	branch, err := n.committer().commit(existing, n.commits(changes, msgs.commit))
+	if err != nil {
 		n.Logger.Log("failed to push commit\n")
 
 		return fmt.Errorf("failed to push commit: %w", err)
 	}
 
-	number, err := n.createPR(branch, "Contains the following PRs: \n"+prNumbers, n.PRTitle)
-	if err != nil {
-		n.Logger.Log("failed to create PR\n")
+	var number *int
+
+	if existing != nil {
+		if err := n.updatePR(existing, msgs.body, msgs.title); err != nil {
+			n.Logger.Log("failed to update PR\n")
 
-		return fmt.Errorf("failed to create pr: %w", err)
+			return fmt.Errorf("failed to update pr: %w", err)
+		}
+
+		number = existing.Number
+	} else {
+		if number, err = n.createPR(branch, msgs.body, msgs.title); err != nil {
+			n.Logger.Log("failed to create PR\n")
+
+			return fmt.Errorf("failed to create pr: %w", err)
+		}
 	}
 
 	if err := n.addLabel(number); err != nil {
@@ -138,20 +379,123 @@ func (n *Bundler) Bundle() error {
 		return fmt.Errorf("failed to add labels: %w", err)
 	}
 
-	// clean up each modified file
-	for k := range modifiedFiles {
-		if output, err := n.Runner.Run("git", ".", "checkout", k); err != nil {
-			n.Logger.Log("failed to run clean, skipping... return error and output of clean command: %s; %s",
-				err.Error(), string(output))
-		}
+	if n.CommentOnPRs {
+		n.commentOnIncluded(*number, numbers(included))
+	}
+
+	if n.CloseSuperseded == CloseOnOpen {
+		n.closeSuperseded(*number, numbers(included), fmt.Sprintf("Superseded by #%d.", *number))
 	}
 
-	n.Logger.Log("PR opened. Thank you for using Bundler, goodbye.\n")
+	n.Logger.Log("PR opened or updated. Thank you for using Bundler, goodbye.\n")
 
 	return nil
 }
 
-func (n *Bundler) getRef() (string, *github.Reference, error) {
+// listCandidates lists the open pull requests of the bots. A pull request which is listed for several bots
+// is only included once, parsed by the first of them.
+func (n *Bundler) listCandidates() ([]candidate, error) {
+	var candidates []candidate
+
+	seen := make(map[int]struct{})
+
+	for _, bot := range n.bots() {
+		requests, err := n.forge().ListChangeRequests(context.Background(), bot.Name)
+		if err != nil {
+			n.Logger.Log("failed to list pull requests of %s\n", bot.Name)
+
+			return nil, fmt.Errorf("failed to list pull requests of %s: %w", bot.Name, err)
+		}
+
+		for _, request := range requests {
+			if _, ok := seen[request.Number]; ok {
+				n.Logger.Debug("PR #%d is already included, skipping\n", request.Number)
+
+				continue
+			}
+
+			seen[request.Number] = struct{}{}
+
+			if !n.trusted(request) {
+				continue
+			}
+
+			candidates = append(candidates, newCandidate(request, bot.Parser))
+		}
+	}
+
+	return candidates, nil
+}
+
+// carryOver returns the open candidates extended with the PRs an existing bundle already contains, if
+// PRs are closed as soon as the bundle is opened. Otherwise, refreshing the bundle would drop them.
+func (n *Bundler) carryOver(existing *github.PullRequest, open []candidate) []candidate {
+	candidates := append([]candidate(nil), open...)
+
+	if existing == nil || n.CloseSuperseded != CloseOnOpen {
+		return candidates
+	}
+
+	seen := make(map[int]struct{}, len(open))
+	for _, c := range open {
+		seen[c.update.Number] = struct{}{}
+	}
+
+	for _, number := range parseIncluded(existing.GetBody()) {
+		if _, ok := seen[number]; ok {
+			continue
+		}
+
+		request, err := n.forge().GetChangeRequest(context.Background(), number)
+		if err != nil {
+			n.Logger.Debug("failed to get pull request for number %d with error %s, skipping \n", number, err)
+
+			continue
+		}
+
+		// only carry over the ones which were closed in favor of the bundle
+		if request.State != forge.StateClosed || !n.trusted(request) {
+			continue
+		}
+
+		candidates = append(candidates, newCandidate(request, ""))
+	}
+
+	return candidates
+}
+
+// findExistingPR looks for an open PR against the target branch which was created by the bundler for
+// the same group. Returns nil if there is no such PR. Outside of GitHub, a new bundle is opened every time.
+// The bundle is pushed to the branch of the PR, so only PRs from branches of the bundler are accepted.
+func (n *Bundler) findExistingPR() (*github.PullRequest, error) {
+	if !n.onGitHub() {
+		return nil, nil
+	}
+
+	prs, _, err := n.Pulls.List(context.Background(), n.Owner, n.Repo, &github.PullRequestListOptions{
+		State: "open",
+		Base:  n.TargetBranch,
+		ListOptions: github.ListOptions{
+			PerPage: defaultNumberOfItemsPerPage,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	for _, pr := range prs {
+		if n.isBundle(pr, n.prefix()) && parseGroup(pr.GetBody()) == n.group {
+			return pr, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getRef returns the branch and the reference to commit to. If there is an existing bundle PR, its
+// branch is reused and the returned reference points to the current base so the branch can be
+// reset onto it. Otherwise, a new branch is created from the base.
+func (n *Bundler) getRef(existing *github.PullRequest) (string, *github.Reference, error) {
 	var (
 		ref     *github.Reference
 		err     error
@@ -162,6 +506,15 @@ func (n *Bundler) getRef() (string, *github.Reference, error) {
 		return "", nil, fmt.Errorf("failed to get ref: %w", err)
 	}
 
+	if existing != nil {
+		branch := existing.GetHead().GetRef()
+
+		return branch, &github.Reference{
+			Ref:    github.String("refs/heads/" + branch),
+			Object: &github.GitObject{SHA: baseRef.Object.SHA},
+		}, nil
+	}
+
 	// random generate commit Branch
 	commitBranch := n.generateCommitBranch()
 
@@ -175,21 +528,80 @@ func (n *Bundler) getRef() (string, *github.Reference, error) {
 		return "", nil, fmt.Errorf("failed to create ref: %w", err)
 	}
 
+	n.undo.add("delete branch "+commitBranch, func() error {
+		return n.deleteBranch(commitBranch)
+	})
+
 	return commitBranch, ref, nil
 }
 
+// isBundle returns whether the PR carries the marker of the bundler and was opened from a branch of the
+// repository itself with one of the prefixes. Anyone can put the marker into the body of a PR, also from a
+// fork, but only the branches of the bundler may be pushed to.
+func (n *Bundler) isBundle(pr *github.PullRequest, prefixes ...string) bool {
+	if !strings.Contains(pr.GetBody(), bundleMarker) ||
+		!strings.EqualFold(pr.GetHead().GetRepo().GetFullName(), n.Owner+"/"+n.Repo) {
+		return false
+	}
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(pr.GetHead().GetRef(), prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// prefix returns the prefix of the branches the bundler creates.
+func (n *Bundler) prefix() string {
+	if n.branchPrefix == "" {
+		return branchPrefix
+	}
+
+	return n.branchPrefix
+}
+
 func (n *Bundler) generateCommitBranch() string {
-	return fmt.Sprintf("bundler-%d", time.Now().UTC().Unix())
+	return fmt.Sprintf("%s%d", n.prefix(), time.Now().UTC().Unix())
 }
 
-func (n *Bundler) getTree(files map[string]struct{}, ref *github.Reference) (*github.Tree, error) {
+func (n *Bundler) getTree(files map[string]fileState, baseTree string) (*github.Tree, error) {
 	// Create a tree with what to commit.
 	var entries []*github.TreeEntry
 
-	for file := range files {
-		content, err := os.ReadFile(file)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read file: %w", err)
+	for file, state := range files {
+		// A deleted file is represented by an entry without SHA and content.
+		if state.deleted {
+			entries = append(
+				entries,
+				&github.TreeEntry{
+					Path: github.String(file),
+					Type: github.String("blob"),
+					Mode: github.String(modeFile),
+				},
+			)
+
+			continue
+		}
+
+		if n.needsBlob(state) {
+			sha, err := n.createBlob(state.content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to upload %s: %w", file, err)
+			}
+
+			entries = append(
+				entries,
+				&github.TreeEntry{
+					Path: github.String(file),
+					Type: github.String("blob"),
+					SHA:  github.String(sha),
+					Mode: github.String(state.mode),
+				},
+			)
+
+			continue
 		}
 
 		entries = append(
@@ -197,13 +609,13 @@ func (n *Bundler) getTree(files map[string]struct{}, ref *github.Reference) (*gi
 			&github.TreeEntry{
 				Path:    github.String(file),
 				Type:    github.String("blob"),
-				Content: github.String(string(content)),
-				Mode:    github.String("100644"),
+				Content: github.String(string(state.content)),
+				Mode:    github.String(state.mode),
 			},
 		)
 	}
 
-	tree, _, err := n.Git.CreateTree(context.Background(), n.Owner, n.Repo, *ref.Object.SHA, entries)
+	tree, _, err := n.Git.CreateTree(context.Background(), n.Owner, n.Repo, baseTree, entries)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tree: %w", err)
 	}
@@ -211,8 +623,9 @@ func (n *Bundler) getTree(files map[string]struct{}, ref *github.Reference) (*gi
 	return tree, nil
 }
 
-// pushCommit creates the commit in the given reference using the given tree.
-func (n *Bundler) pushCommit(ref *github.Reference, tree *github.Tree) (err error) {
+// pushCommits creates the commits one after the other on top of the given reference and
+// moves the reference to the last one.
+func (n *Bundler) pushCommits(ref *github.Reference, commits []commitSpec, force bool) error {
 	// Get the parent commit to attach the commit to.
 	parent, _, err := n.Repositories.GetCommit(context.Background(), n.Owner, n.Repo, *ref.Object.SHA, nil)
 	if err != nil {
@@ -221,22 +634,46 @@ func (n *Bundler) pushCommit(ref *github.Reference, tree *github.Tree) (err erro
 	// This is not always populated, but is needed.
 	parent.Commit.SHA = parent.SHA
 
-	// Create the commit using the tree.
+	head := parent.Commit
+	baseTree := *ref.Object.SHA
+
+	for _, c := range commits {
+		tree, err := n.getTree(c.files, baseTree)
+		if err != nil {
+			return fmt.Errorf("failed to get tree: %w", err)
+		}
+
+		if head, err = n.createCommit(head, tree, c.message); err != nil {
+			return err
+		}
+
+		baseTree = tree.GetSHA()
+	}
+
+	ref.Object.SHA = head.SHA
+	if _, _, err = n.Git.UpdateRef(context.Background(), n.Owner, n.Repo, ref, force); err != nil {
+		return fmt.Errorf("failed to update ref: %w", err)
+	}
+
+	return nil
+}
+
+// createCommit creates a commit using the tree on top of the parent.
+func (n *Bundler) createCommit(parent *github.Commit, tree *github.Tree, commitMessage string) (*github.Commit, error) {
 	date := time.Now()
-	commitMessage := "Bundling updated dependencies."
 	author := &github.CommitAuthor{Date: &date, Name: &n.AuthorName, Email: &n.AuthorEmail}
 	commit := &github.Commit{
 		Author:  author,
 		Message: &commitMessage,
 		Tree:    tree,
-		Parents: []*github.Commit{parent.Commit},
+		Parents: []*github.Commit{parent},
 	}
 
 	// if signing key is provided...
 	if n.Signer != nil {
 		entity, err := n.Signer.GetEntity()
 		if err != nil {
-			return fmt.Errorf("failed to get entity for signing details: %w", err)
+			return nil, fmt.Errorf("failed to get entity for signing details: %w", err)
 		}
 
 		commit.SigningKey = entity
@@ -244,53 +681,57 @@ func (n *Bundler) pushCommit(ref *github.Reference, tree *github.Tree) (err erro
 
 	newCommit, _, err := n.Git.CreateCommit(context.Background(), n.Owner, n.Repo, commit)
 	if err != nil {
-		return fmt.Errorf("failed to create commit: %w", err)
+		return nil, fmt.Errorf("failed to create commit: %w", err)
 	}
 
-	ref.Object.SHA = newCommit.SHA
-	if _, _, err = n.Git.UpdateRef(context.Background(), n.Owner, n.Repo, ref, false); err != nil {
-		return fmt.Errorf("failed to update ref: %w", err)
-	}
-
-	return nil
+	return newCommit, nil
 }
 
 func (n *Bundler) createPR(commitBranch string, description string, title string) (*int, error) {
-	newPR := &github.NewPullRequest{
-		Title:               &title,
-		Head:                &commitBranch,
-		Base:                &n.TargetBranch,
-		Body:                &description,
-		MaintainerCanModify: github.Bool(true),
-	}
-
-	createdPR, _, err := n.Pulls.Create(context.Background(), n.Owner, n.Repo, newPR)
+	created, err := n.forge().OpenChangeRequest(context.Background(), forge.NewChangeRequest{
+		Title:        title,
+		Body:         description,
+		SourceBranch: commitBranch,
+		TargetBranch: n.TargetBranch,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pull request: %w", err)
 	}
 
-	fmt.Printf("PR created: %s\n", createdPR.GetHTMLURL())
+	if n.onGitHub() {
+		n.undo.add(fmt.Sprintf("close PR #%d", created.Number), func() error {
+			return n.closePR(created.Number)
+		})
+	}
 
-	return createdPR.Number, nil
-}
+	fmt.Printf("PR created: %s\n", created.URL)
 
-func (n *Bundler) logErrorWithBody(err error, body io.ReadCloser) error {
-	content, bodyErr := io.ReadAll(body)
-	if bodyErr != nil {
-		n.Logger.Log("failed to read body from github response\n")
+	return &created.Number, nil
+}
 
-		return bodyErr
+func (n *Bundler) updatePR(pr *github.PullRequest, description string, title string) error {
+	if err := n.editPR(pr.GetNumber(), description, title); err != nil {
+		return err
 	}
 
-	defer func() {
-		if err := body.Close(); err != nil {
-			n.Logger.Log("failed to close body\n")
-		}
-	}()
+	n.undo.add(fmt.Sprintf("restore title and description of PR #%d", pr.GetNumber()), func() error {
+		return n.editPR(pr.GetNumber(), pr.GetBody(), pr.GetTitle())
+	})
 
-	n.Logger.Log("got response from github: %s\n", string(content))
+	fmt.Printf("PR updated: %s\n", pr.GetHTMLURL())
 
-	return fmt.Errorf("failed to log response body: %w", err)
+	return nil
+}
+
+func (n *Bundler) editPR(number int, description string, title string) error {
+	if _, _, err := n.Pulls.Edit(context.Background(), n.Owner, n.Repo, number, &github.PullRequest{
+		Title: &title,
+		Body:  &description,
+	}); err != nil {
+		return fmt.Errorf("failed to edit pull request: %w", err)
+	}
+
+	return nil
 }
 
 func (n *Bundler) addLabel(number *int) error {
@@ -300,7 +741,7 @@ func (n *Bundler) addLabel(number *int) error {
 		return nil
 	}
 
-	if _, _, err := n.Issues.AddLabelsToIssue(context.Background(), n.Owner, n.Repo, *number, n.Labels); err != nil {
+	if err := n.forge().AddLabels(context.Background(), *number, n.Labels); err != nil {
 		return fmt.Errorf("failed to add lables to issue: %w", err)
 	}
 
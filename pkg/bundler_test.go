@@ -1,7 +1,12 @@
 package pkg_test
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v43/github"
 	"github.com/stretchr/testify/assert"
@@ -9,11 +14,32 @@ import (
 
 	"github.com/Skarlso/dependabot-bundler/pkg"
 	"github.com/Skarlso/dependabot-bundler/pkg/api/fakes"
+	"github.com/Skarlso/dependabot-bundler/pkg/forge"
+	forgeFakes "github.com/Skarlso/dependabot-bundler/pkg/forge/fakes"
 	"github.com/Skarlso/dependabot-bundler/pkg/logger"
+	"github.com/Skarlso/dependabot-bundler/pkg/providers"
 	providerFakes "github.com/Skarlso/dependabot-bundler/pkg/providers/fakes"
 )
 
-func TestBundler(t *testing.T) {
+type testFakes struct {
+	git          *fakes.FakeGit
+	repositories *fakes.FakeRepositories
+	issues       *fakes.FakeIssues
+	pulls        *fakes.FakePullRequests
+	updater      *providerFakes.FakeUpdater
+	runner       *providerFakes.FakeRunner
+}
+
+// bundleHead returns the head of a bundle PR on the branch of the test repository.
+func bundleHead(ref string) *github.PullRequestBranch {
+	return &github.PullRequestBranch{
+		Ref:  github.String(ref),
+		Repo: &github.Repository{FullName: github.String("owner/repo")},
+	}
+}
+
+// newTestBundler returns a bundler which is set up with fakes for a single dependabot PR to bundle.
+func newTestBundler() (*pkg.Bundler, *testFakes) {
 	fakeGit := &fakes.FakeGit{}
 	fakeRepositories := &fakes.FakeRepositories{}
 	fakeIssues := &fakes.FakeIssues{}
@@ -114,16 +140,920 @@ func TestBundler(t *testing.T) {
 	}, nil, nil)
 
 	fakeIssues.AddLabelsToIssueReturns(nil, nil, nil)
+	fakeRunner.RunStub = worktreeStub
+
+	return bundler, &testFakes{
+		git:          fakeGit,
+		repositories: fakeRepositories,
+		issues:       fakeIssues,
+		pulls:        fakePulls,
+		updater:      fakeUpdater,
+		runner:       fakeRunner,
+	}
+}
+
+// trustedArgs are passed to every git command, so repositories owned by another user can be used.
+var trustedArgs = []string{"-c", "safe.directory=*"}
+
+// gitArgs returns the arguments of a git command without trustedArgs.
+func gitArgs(args []string) []string {
+	if len(args) >= len(trustedArgs) && args[0] == trustedArgs[0] && args[1] == trustedArgs[1] {
+		return args[len(trustedArgs):]
+	}
+
+	return args
+}
+
+// worktreeStub simulates `git worktree add` by copying the testdata folder into the worktree.
+func worktreeStub(_, workdir string, args ...string) ([]byte, error) {
+	const worktreeArgs = 5
+
+	args = gitArgs(args)
+	if len(args) < worktreeArgs || args[0] != "worktree" || args[1] != "add" {
+		return nil, nil
+	}
+
+	source := filepath.Join(workdir, "testdata")
+	target := filepath.Join(args[3], "testdata")
+
+	return nil, filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(target, rel), 0o750)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(filepath.Join(target, rel), content, info.Mode())
+	})
+}
+
+func TestBundler(t *testing.T) {
+	bundler, f := newTestBundler()
 
 	require.NoError(t, bundler.Bundle())
 
-	body, branch, title := fakeUpdater.UpdateArgsForCall(0)
+	body, branch, title := f.updater.UpdateArgsForCall(0)
 	assert.Equal(t, "test-title", title)
 	assert.Equal(t, "Bumps [github.com/test/test](github.com/test/test)", body)
 	assert.Equal(t, "dependabot/go_modules/github.com/aws/aws-sdk-go-v2/service/ssm-1.27.0", branch)
-	_, owner, repo, number, labels := fakeIssues.AddLabelsToIssueArgsForCall(0)
+	_, owner, repo, number, labels := f.issues.AddLabelsToIssueArgsForCall(0)
 	assert.Equal(t, "owner", owner)
 	assert.Equal(t, "repo", repo)
 	assert.Equal(t, 1, number)
 	assert.Equal(t, []string{"label1", "label2"}, labels)
+	assert.Equal(t, 1, f.git.CreateRefCallCount())
+	assertWorktree(t, f.runner)
+	assert.Equal(t, 1, f.pulls.CreateCallCount())
+	assert.Equal(t, 0, f.pulls.EditCallCount())
+	_, _, _, _, force := f.git.UpdateRefArgsForCall(0)
+	assert.False(t, force)
+	_, _, _, newPR := f.pulls.CreateArgsForCall(0)
+	assert.Contains(t, newPR.GetBody(), "#1")
+	assert.Contains(t, newPR.GetBody(), "<!-- dependabot-bundler -->")
+}
+
+func TestBundlerUpdatesExistingPR(t *testing.T) {
+	bundler, f := newTestBundler()
+	f.pulls.ListReturns([]*github.PullRequest{
+		{
+			Number: github.Int(2),
+			Body:   github.String("some other PR"),
+			Head:   &github.PullRequestBranch{Ref: github.String("feature")},
+		},
+		{
+			Number: github.Int(3),
+			Body:   github.String("Contains the following PRs: \n#1\n\n<!-- dependabot-bundler -->"),
+			Head:   bundleHead("bundler-12345"),
+		},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	assert.Equal(t, 0, f.git.CreateRefCallCount())
+	assert.Equal(t, 0, f.pulls.CreateCallCount())
+	_, _, _, ref, force := f.git.UpdateRefArgsForCall(0)
+	assert.True(t, force)
+	assert.Equal(t, "refs/heads/bundler-12345", ref.GetRef())
+	_, _, _, number, pr := f.pulls.EditArgsForCall(0)
+	assert.Equal(t, 3, number)
+	assert.Contains(t, pr.GetBody(), "#1")
+	assert.Contains(t, pr.GetBody(), "<!-- dependabot-bundler -->")
+	_, _, _, labelNumber, _ := f.issues.AddLabelsToIssueArgsForCall(0)
+	assert.Equal(t, 3, labelNumber)
+}
+
+func TestBundlerIgnoresForeignBundles(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.CloseSuperseded = pkg.CloseOnOpen
+	f.pulls.ListReturns([]*github.PullRequest{
+		{
+			// a fork can name its branch like the target branch and copy the marker
+			Number: github.Int(2),
+			Body:   github.String("<!-- dependabot-bundler -->\n<!-- dependabot-bundler-prs: 5 -->"),
+			Head: &github.PullRequestBranch{
+				Ref:  github.String("main"),
+				Repo: &github.Repository{FullName: github.String("attacker/repo")},
+			},
+		},
+		{
+			Number: github.Int(3),
+			Body:   github.String("<!-- dependabot-bundler -->\n<!-- dependabot-bundler-prs: 5 -->"),
+			Head: &github.PullRequestBranch{
+				Ref:  github.String("bundler-12345"),
+				Repo: &github.Repository{FullName: github.String("attacker/repo")},
+			},
+		},
+		{
+			// a branch of the repository which the bundler didn't create
+			Number: github.Int(4),
+			Body:   github.String("<!-- dependabot-bundler -->"),
+			Head:   bundleHead("release-1.0"),
+		},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	// a new bundle is opened, the marked PRs are left alone
+	require.Equal(t, 1, f.git.CreateRefCallCount())
+	_, _, _, ref := f.git.CreateRefArgsForCall(0)
+	assert.Regexp(t, `^refs/heads/bundler-\d+$`, ref.GetRef())
+	assert.Equal(t, 1, f.pulls.CreateCallCount())
+	assert.Equal(t, 1, f.updater.UpdateCallCount())
+
+	for i := 0; i < f.pulls.EditCallCount(); i++ {
+		_, _, _, number, _ := f.pulls.EditArgsForCall(i)
+		assert.Equal(t, 1, number)
+	}
+}
+
+func TestBundlerClosesSupersededOnOpen(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.CloseSuperseded = pkg.CloseOnOpen
+	f.pulls.CreateReturns(&github.PullRequest{
+		HTMLURL: github.String("https://github.com/test/test/pulls/10"),
+		Number:  github.Int(10),
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	_, _, _, newPR := f.pulls.CreateArgsForCall(0)
+	assert.Contains(t, newPR.GetBody(), "<!-- dependabot-bundler-prs: 1 -->")
+	_, _, _, number, comment := f.issues.CreateCommentArgsForCall(0)
+	assert.Equal(t, 1, number)
+	assert.Equal(t, "Superseded by #10.\n\n<!-- dependabot-bundler-comment -->", comment.GetBody())
+	_, _, _, number, pr := f.pulls.EditArgsForCall(0)
+	assert.Equal(t, 1, number)
+	assert.Equal(t, "closed", pr.GetState())
+}
+
+func TestBundlerCarriesOverClosedPRsOnOpen(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.CloseSuperseded = pkg.CloseOnOpen
+	f.pulls.ListReturns([]*github.PullRequest{
+		{
+			Number: github.Int(3),
+			Body:   github.String("<!-- dependabot-bundler -->\n<!-- dependabot-bundler-prs: 1,2 -->"),
+			Head:   bundleHead("bundler-12345"),
+		},
+	}, nil, nil)
+	f.pulls.GetReturnsOnCall(1, &github.PullRequest{
+		Number: github.Int(2),
+		State:  github.String("closed"),
+		Title:  github.String("closed-title"),
+		Body:   github.String("Bumps [actions/checkout](https://github.com/actions/checkout) from 2 to 3"),
+		Head: &github.PullRequestBranch{
+			Ref: github.String("dependabot/github_actions/actions/checkout-3"),
+		},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	require.Equal(t, 2, f.updater.UpdateCallCount())
+	body, branch, title := f.updater.UpdateArgsForCall(1)
+	assert.Equal(t, "closed-title", title)
+	assert.Equal(t, "Bumps [actions/checkout](https://github.com/actions/checkout) from 2 to 3", body)
+	assert.Equal(t, "dependabot/github_actions/actions/checkout-3", branch)
+	_, _, _, number, pr := f.pulls.EditArgsForCall(0)
+	assert.Equal(t, 3, number)
+	assert.Contains(t, pr.GetBody(), "<!-- dependabot-bundler-prs: 1,2 -->")
+}
+
+func TestBundlerClosesPRsOfMergedBundles(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.CloseSuperseded = pkg.CloseOnMerge
+	f.pulls.ListReturnsOnCall(0, []*github.PullRequest{
+		{
+			Number: github.Int(4),
+			Body:   github.String("<!-- dependabot-bundler -->\n<!-- dependabot-bundler-prs: 7 -->"),
+		},
+		{
+			Number:   github.Int(5),
+			Body:     github.String("<!-- dependabot-bundler -->\n<!-- dependabot-bundler-prs: 8 -->"),
+			Head:     bundleHead("bundler-12345"),
+			MergedAt: &time.Time{},
+		},
+	}, nil, nil)
+	f.pulls.GetReturnsOnCall(0, &github.PullRequest{
+		Number: github.Int(8),
+		State:  github.String("open"),
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	_, _, _, opts := f.pulls.ListArgsForCall(0)
+	assert.Equal(t, "closed", opts.State)
+	_, _, _, number := f.pulls.GetArgsForCall(0)
+	assert.Equal(t, 8, number)
+	require.Equal(t, 1, f.issues.CreateCommentCallCount())
+	_, _, _, number, comment := f.issues.CreateCommentArgsForCall(0)
+	assert.Equal(t, 8, number)
+	assert.Equal(t, "Included in #5 which has been merged.\n\n<!-- dependabot-bundler-comment -->", comment.GetBody())
+	_, _, _, number, pr := f.pulls.EditArgsForCall(0)
+	assert.Equal(t, 8, number)
+	assert.Equal(t, "closed", pr.GetState())
+}
+
+func TestBundlerCommentsOnPRs(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.CommentOnPRs = true
+	f.pulls.CreateReturns(&github.PullRequest{
+		HTMLURL: github.String("https://github.com/test/test/pulls/10"),
+		Number:  github.Int(10),
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	require.Equal(t, 1, f.issues.CreateCommentCallCount())
+	assert.Equal(t, 0, f.issues.EditCommentCallCount())
+	_, _, _, number, comment := f.issues.CreateCommentArgsForCall(0)
+	assert.Equal(t, 1, number)
+	assert.Equal(t, "Included in #10.\n\n<!-- dependabot-bundler-comment -->", comment.GetBody())
+}
+
+func TestBundlerEditsExistingComment(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.CommentOnPRs = true
+	f.pulls.CreateReturns(&github.PullRequest{
+		HTMLURL: github.String("https://github.com/test/test/pulls/10"),
+		Number:  github.Int(10),
+	}, nil, nil)
+	f.issues.ListCommentsReturns([]*github.IssueComment{
+		{
+			ID:   github.Int64(100),
+			Body: github.String("LGTM"),
+		},
+		{
+			ID:   github.Int64(101),
+			Body: github.String("Included in #9.\n\n<!-- dependabot-bundler-comment -->"),
+		},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	assert.Equal(t, 0, f.issues.CreateCommentCallCount())
+	require.Equal(t, 1, f.issues.EditCommentCallCount())
+	_, _, _, id, comment := f.issues.EditCommentArgsForCall(0)
+	assert.Equal(t, int64(101), id)
+	assert.Equal(t, "Included in #10.\n\n<!-- dependabot-bundler-comment -->", comment.GetBody())
+}
+
+func TestBundlerTemplates(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.PRTitleTemplate = "chore(deps): bundle {{ .Count }} updates into {{ .TargetBranch }}"
+	bundler.PRBodyTemplate = "{{ range .Updates }}- {{ .Dependency }} (#{{ .Number }})\n{{ end }}"
+	bundler.CommitMessageTemplate = "chore(deps): bundle {{ .Count }} updates"
+
+	require.NoError(t, bundler.Bundle())
+
+	_, _, _, newPR := f.pulls.CreateArgsForCall(0)
+	assert.Equal(t, "chore(deps): bundle 1 updates into main", newPR.GetTitle())
+	assert.Equal(t, "- github.com/test/test (#1)\n\n<!-- dependabot-bundler -->\n<!-- dependabot-bundler-prs: 1 -->", newPR.GetBody())
+	_, _, _, commit := f.git.CreateCommitArgsForCall(0)
+	assert.Equal(t, "chore(deps): bundle 1 updates", commit.GetMessage())
+}
+
+func TestBundlerInvalidTemplate(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.PRBodyTemplate = "{{ .Unknown }}"
+
+	assert.ErrorContains(t, bundler.Bundle(), "failed to execute pr-body template")
+	assert.Equal(t, 0, f.git.CreateRefCallCount())
+}
+
+func TestBundlerCommitPerDependency(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.CommitPerDependency = true
+	f.issues.ListByRepoReturns([]*github.Issue{
+		{
+			Number:           github.Int(1),
+			Body:             github.String("Bumps [github.com/test/test](github.com/test/test)"),
+			PullRequestLinks: &github.PullRequestLinks{},
+		},
+		{
+			Number:           github.Int(2),
+			Body:             github.String("Bumps [github.com/test/other](github.com/test/other)"),
+			PullRequestLinks: &github.PullRequestLinks{},
+		},
+	}, &github.Response{}, nil)
+	f.pulls.GetReturnsOnCall(0, &github.PullRequest{Title: github.String("Bump github.com/test/test")}, nil, nil)
+	f.pulls.GetReturnsOnCall(1, &github.PullRequest{Title: github.String("Bump github.com/test/other")}, nil, nil)
+	f.updater.UpdateReturns([]providers.FileChange{{Path: "testdata/go.mod", Kind: providers.Modified}}, nil)
+	f.git.CreateTreeReturnsOnCall(0, &github.Tree{SHA: github.String("tree1")}, nil, nil)
+	f.git.CreateTreeReturnsOnCall(1, &github.Tree{SHA: github.String("tree2")}, nil, nil)
+	f.git.CreateCommitReturnsOnCall(0, &github.Commit{SHA: github.String("commit1")}, nil, nil)
+	f.git.CreateCommitReturnsOnCall(1, &github.Commit{SHA: github.String("commit2")}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	require.Equal(t, 2, f.git.CreateTreeCallCount())
+	_, _, _, baseTree, entries := f.git.CreateTreeArgsForCall(0)
+	assert.Equal(t, "aa218f56b14c9653891f9e74264a383fa43fefbd", baseTree)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "testdata/go.mod", entries[0].GetPath())
+	_, _, _, baseTree, _ = f.git.CreateTreeArgsForCall(1)
+	assert.Equal(t, "tree1", baseTree)
+
+	require.Equal(t, 2, f.git.CreateCommitCallCount())
+	_, _, _, commit := f.git.CreateCommitArgsForCall(0)
+	assert.Equal(t, "Bump github.com/test/test\n\nCloses #1", commit.GetMessage())
+	assert.Equal(t, "tree1", commit.GetTree().GetSHA())
+	_, _, _, commit = f.git.CreateCommitArgsForCall(1)
+	assert.Equal(t, "Bump github.com/test/other\n\nCloses #2", commit.GetMessage())
+	assert.Equal(t, "commit1", commit.Parents[0].GetSHA())
+
+	_, _, _, ref, _ := f.git.UpdateRefArgsForCall(0)
+	assert.Equal(t, "commit2", ref.GetObject().GetSHA())
+}
+
+func TestBundlerTreeEntries(t *testing.T) {
+	bundler, f := newTestBundler()
+	f.updater.UpdateReturns([]providers.FileChange{
+		{Path: "testdata/go.mod", Kind: providers.Modified},
+		{Path: "testdata/script.sh", Kind: providers.ModeChanged},
+		{Path: "testdata/removed.txt", Kind: providers.Deleted},
+	}, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	_, _, _, _, entries := f.git.CreateTreeArgsForCall(0)
+	require.Len(t, entries, 3)
+
+	byPath := make(map[string]*github.TreeEntry)
+	for _, e := range entries {
+		byPath[e.GetPath()] = e
+	}
+
+	assert.Equal(t, "100644", byPath["testdata/go.mod"].GetMode())
+	assert.Equal(t, "module test\n", byPath["testdata/go.mod"].GetContent())
+	assert.Equal(t, "100755", byPath["testdata/script.sh"].GetMode())
+	assert.Nil(t, byPath["testdata/removed.txt"].SHA)
+	assert.Nil(t, byPath["testdata/removed.txt"].Content)
+
+	// deletions have to be sent with a null SHA
+	content, err := byPath["testdata/removed.txt"].MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"sha":null`)
+}
+
+func TestBundlerUploadsBlobs(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.BlobThreshold = 5
+	f.updater.UpdateReturns([]providers.FileChange{
+		{Path: "testdata/go.mod", Kind: providers.Modified},
+		{Path: "testdata/binary.bin", Kind: providers.Modified},
+	}, nil)
+	f.git.CreateBlobReturns(&github.Blob{SHA: github.String("blobsha")}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	require.Equal(t, 2, f.git.CreateBlobCallCount())
+
+	contents := make(map[string]bool)
+	for i := 0; i < f.git.CreateBlobCallCount(); i++ {
+		_, _, _, blob := f.git.CreateBlobArgsForCall(i)
+		assert.Equal(t, "base64", blob.GetEncoding())
+		contents[blob.GetContent()] = true
+	}
+
+	assert.True(t, contents["bW9kdWxlIHRlc3QK"], "go.mod above the threshold")
+	assert.True(t, contents["//4AAQ=="], "binary file")
+
+	_, _, _, _, entries := f.git.CreateTreeArgsForCall(0)
+	require.Len(t, entries, 2)
+
+	for _, e := range entries {
+		assert.Equal(t, "blobsha", e.GetSHA())
+		assert.Nil(t, e.Content)
+	}
+}
+
+func TestBundlerInlinesSmallTextFiles(t *testing.T) {
+	bundler, f := newTestBundler()
+	f.updater.UpdateReturns([]providers.FileChange{{Path: "testdata/go.mod", Kind: providers.Modified}}, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	assert.Equal(t, 0, f.git.CreateBlobCallCount())
+}
+
+func TestBundlerGitBackend(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.Backend = pkg.BackendGit
+	bundler.CommitPerDependency = true
+	bundler.GitSigningKey = "ABCDEF"
+	f.issues.ListByRepoReturns([]*github.Issue{
+		{Number: github.Int(1), PullRequestLinks: &github.PullRequestLinks{}},
+		{Number: github.Int(2), PullRequestLinks: &github.PullRequestLinks{}},
+	}, &github.Response{}, nil)
+	f.pulls.GetReturnsOnCall(0, &github.PullRequest{Title: github.String("Bump first")}, nil, nil)
+	f.pulls.GetReturnsOnCall(1, &github.PullRequest{Title: github.String("Bump second")}, nil, nil)
+
+	file := filepath.Join(t.TempDir(), "go.mod")
+	f.updater.UpdateStub = func(string, string, string) ([]providers.FileChange, error) {
+		// each update adds a line
+		content, _ := os.ReadFile(file)
+		require.NoError(t, os.WriteFile(file, append(content, []byte("line\n")...), 0o600))
+
+		return []providers.FileChange{{Path: file, Kind: providers.Modified}}, nil
+	}
+
+	var contents []string
+
+	f.runner.RunStub = func(command, workdir string, args ...string) ([]byte, error) {
+		if args[len(args)-1] == file {
+			content, err := os.ReadFile(file)
+			require.NoError(t, err)
+			contents = append(contents, string(content))
+		}
+
+		return nil, nil
+	}
+
+	require.NoError(t, bundler.Bundle())
+
+	assert.Equal(t, 0, f.git.CreateRefCallCount())
+	assert.Equal(t, 0, f.git.CreateCommitCallCount())
+
+	var commands [][]string
+
+	for i := 0; i < f.runner.RunCallCount(); i++ {
+		command, _, args := f.runner.RunArgsForCall(i)
+		commands = append(commands, append([]string{command}, gitArgs(args)...))
+	}
+
+	assertWorktree(t, f.runner)
+
+	// skip creating and removing the worktree
+	commands = commands[2 : len(commands)-1]
+	require.Len(t, commands, 5)
+	assert.Equal(t, []string{"git", "add", "-A", "--", file}, commands[0])
+	assert.Equal(t, []string{
+		"git", "-c", "user.name=author", "-c", "user.email=author@git.com",
+		"commit", "-m", "Bump first\n\nCloses #1", "--gpg-sign=ABCDEF",
+	}, commands[1])
+	assert.Equal(t, []string{"git", "add", "-A", "--", file}, commands[2])
+	assert.Equal(t, "Bump second\n\nCloses #2", commands[3][7])
+	// no local branch is created, the commits are pushed from the detached HEAD
+	assert.Equal(t, []string{"git", "push", "origin"}, commands[4][:3])
+	branch := strings.TrimPrefix(commands[4][3], "HEAD:refs/heads/")
+	assert.Regexp(t, `^bundler-\d+$`, branch)
+
+	// the first commit only contains the first update
+	assert.Equal(t, []string{"line\n", "line\nline\n"}, contents[:2])
+
+	_, _, _, newPR := f.pulls.CreateArgsForCall(0)
+	assert.Equal(t, branch, newPR.GetHead())
+}
+
+// assertWorktree checks that a worktree was created from the target branch and removed again.
+func assertWorktree(t *testing.T, runner *providerFakes.FakeRunner) {
+	t.Helper()
+
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, runner.RunCallCount(), 3)
+	command, workdir, args := runner.RunArgsForCall(0)
+	assert.Equal(t, "git", command)
+	assert.Equal(t, cwd, workdir)
+	assert.Equal(t, append(trustedArgs, "fetch", "origin", "main"), args)
+	_, _, args = runner.RunArgsForCall(1)
+	args = gitArgs(args)
+	require.Len(t, args, 5)
+	assert.Equal(t, []string{"worktree", "add", "--detach"}, args[:3])
+	assert.Equal(t, "FETCH_HEAD", args[4])
+	dir := args[3]
+	_, workdir, args = runner.RunArgsForCall(runner.RunCallCount() - 1)
+	args = gitArgs(args)
+	assert.Equal(t, cwd, workdir)
+	assert.Equal(t, []string{"worktree", "remove", "--force", dir}, args)
+	assert.NoDirExists(t, dir)
+}
+
+func TestBundlerRemovesWorktreeOnFailure(t *testing.T) {
+	bundler, f := newTestBundler()
+	f.git.CreateRefReturns(nil, nil, errors.New("nope"))
+
+	assert.ErrorContains(t, bundler.Bundle(), "nope")
+	assertWorktree(t, f.runner)
+}
+
+func TestBundlerWorktreeFailure(t *testing.T) {
+	bundler, f := newTestBundler()
+	f.runner.RunStub = func(string, string, ...string) ([]byte, error) {
+		return []byte("fatal: not a git repository"), errors.New("exit status 128")
+	}
+
+	assert.ErrorContains(t, bundler.Bundle(), "failed to create worktree")
+	assert.Equal(t, 0, f.updater.UpdateCallCount())
+}
+
+func TestBundlerRollback(t *testing.T) {
+	tests := []struct {
+		name         string
+		setup        func(f *testFakes)
+		err          string
+		deletedRef   bool
+		closedPR     bool
+		restoredRefs int
+	}{
+		{
+			name: "commit fails",
+			setup: func(f *testFakes) {
+				f.git.CreateCommitReturns(nil, nil, errors.New("commit failed"))
+			},
+			err:        "commit failed",
+			deletedRef: true,
+		},
+		{
+			name: "creating the PR fails",
+			setup: func(f *testFakes) {
+				f.pulls.CreateReturns(nil, nil, errors.New("create failed"))
+			},
+			err:        "create failed",
+			deletedRef: true,
+		},
+		{
+			name: "adding labels fails",
+			setup: func(f *testFakes) {
+				f.issues.AddLabelsToIssueReturns(nil, nil, errors.New("labels failed"))
+			},
+			err:        "labels failed",
+			deletedRef: true,
+			closedPR:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundler, f := newTestBundler()
+			tt.setup(f)
+
+			assert.ErrorContains(t, bundler.Bundle(), tt.err)
+
+			if tt.deletedRef {
+				require.Equal(t, 1, f.git.DeleteRefCallCount())
+				_, _, _, ref := f.git.DeleteRefArgsForCall(0)
+				_, _, _, newRef := f.git.CreateRefArgsForCall(0)
+				assert.Equal(t, newRef.GetRef(), ref)
+			} else {
+				assert.Equal(t, 0, f.git.DeleteRefCallCount())
+			}
+
+			if tt.closedPR {
+				require.Equal(t, 1, f.pulls.EditCallCount())
+				_, _, _, number, pr := f.pulls.EditArgsForCall(0)
+				assert.Equal(t, 1, number)
+				assert.Equal(t, "closed", pr.GetState())
+			} else {
+				assert.Equal(t, 0, f.pulls.EditCallCount())
+			}
+
+			assertWorktree(t, f.runner)
+		})
+	}
+}
+
+func TestBundlerRollbackExistingPR(t *testing.T) {
+	bundler, f := newTestBundler()
+	f.pulls.ListReturns([]*github.PullRequest{
+		{
+			Number: github.Int(3),
+			Title:  github.String("old title"),
+			Body:   github.String("old body\n<!-- dependabot-bundler -->"),
+			Head: &github.PullRequestBranch{
+				Ref:  github.String("bundler-12345"),
+				SHA:  github.String("oldsha"),
+				Repo: &github.Repository{FullName: github.String("owner/repo")},
+			},
+		},
+	}, nil, nil)
+	f.issues.AddLabelsToIssueReturns(nil, nil, errors.New("labels failed"))
+
+	assert.ErrorContains(t, bundler.Bundle(), "labels failed")
+
+	assert.Equal(t, 0, f.git.DeleteRefCallCount())
+	require.Equal(t, 2, f.pulls.EditCallCount())
+	_, _, _, number, pr := f.pulls.EditArgsForCall(1)
+	assert.Equal(t, 3, number)
+	assert.Equal(t, "old title", pr.GetTitle())
+	assert.Equal(t, "old body\n<!-- dependabot-bundler -->", pr.GetBody())
+	require.Equal(t, 2, f.git.UpdateRefCallCount())
+	_, _, _, ref, force := f.git.UpdateRefArgsForCall(1)
+	assert.True(t, force)
+	assert.Equal(t, "refs/heads/bundler-12345", ref.GetRef())
+	assert.Equal(t, "oldsha", ref.GetObject().GetSHA())
+}
+
+func TestBundlerKeepOnFailure(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.KeepOnFailure = true
+	f.issues.AddLabelsToIssueReturns(nil, nil, errors.New("labels failed"))
+
+	assert.ErrorContains(t, bundler.Bundle(), "labels failed")
+
+	assert.Equal(t, 0, f.git.DeleteRefCallCount())
+	assert.Equal(t, 0, f.pulls.EditCallCount())
+
+	// the worktree is kept as well
+	_, _, args := f.runner.RunArgsForCall(f.runner.RunCallCount() - 1)
+	args = gitArgs(args)
+	require.Len(t, args, 5)
+	assert.Equal(t, []string{"worktree", "add"}, args[:2])
+	assert.DirExists(t, args[3])
+	require.NoError(t, os.RemoveAll(args[3]))
+}
+
+func TestBundlerForge(t *testing.T) {
+	bundler, f := newTestBundler()
+	fakeForge := &forgeFakes.FakeForge{}
+	bundler.Forge = fakeForge
+	bundler.BotName = "dependabot"
+	fakeForge.ListChangeRequestsReturns([]*forge.ChangeRequest{
+		{
+			Number:       4,
+			URL:          "https://gitlab.com/owner/repo/-/merge_requests/4",
+			Title:        "Bump github.com/test/test from 1.0.0 to 1.1.0",
+			Body:         "Bumps [github.com/test/test](github.com/test/test) from 1.0.0 to 1.1.0.",
+			SourceBranch: "dependabot-go_modules-.-github.com-test-test-1.1.0",
+			State:        forge.StateOpen,
+		},
+	}, nil)
+	fakeForge.OpenChangeRequestReturns(&forge.ChangeRequest{Number: 5}, nil)
+	fakeForge.ReferencePrefixReturns("!")
+	bundler.CommitPerDependency = true
+	f.updater.UpdateReturns([]providers.FileChange{
+		{Path: "testdata/go.mod", Kind: providers.Modified},
+		{Path: "testdata/script.sh", Kind: providers.Added},
+		{Path: "testdata/removed.txt", Kind: providers.Deleted},
+	}, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	_, author := fakeForge.ListChangeRequestsArgsForCall(0)
+	assert.Equal(t, "dependabot", author)
+	body, branch, _ := f.updater.UpdateArgsForCall(0)
+	assert.Contains(t, body, "Bumps [github.com/test/test]")
+	assert.Equal(t, "dependabot-go_modules-.-github.com-test-test-1.1.0", branch)
+
+	require.Equal(t, 1, fakeForge.CreateBranchCallCount())
+	_, created, base := fakeForge.CreateBranchArgsForCall(0)
+	assert.Contains(t, created, "bundler-")
+	assert.Equal(t, "main", base)
+
+	require.Equal(t, 1, fakeForge.CommitFilesCallCount())
+	_, commit := fakeForge.CommitFilesArgsForCall(0)
+	assert.Equal(t, created, commit.Branch)
+	assert.Equal(t, "author", commit.AuthorName)
+	// merge requests are referenced with ! on GitLab
+	assert.Equal(t, "Bump github.com/test/test from 1.0.0 to 1.1.0\n\nCloses !4", commit.Message)
+	assert.Equal(t, []forge.File{
+		{Path: "testdata/go.mod", Content: []byte("module test\n")},
+		{Path: "testdata/removed.txt", Deleted: true},
+		{Path: "testdata/script.sh", Content: []byte("#!/bin/sh\necho \"test\"\n"), Executable: true, Added: true},
+	}, commit.Files)
+
+	_, request := fakeForge.OpenChangeRequestArgsForCall(0)
+	assert.Equal(t, created, request.SourceBranch)
+	assert.Equal(t, "main", request.TargetBranch)
+	assert.Contains(t, request.Body, "`github.com/test/test` | go_modules")
+	assert.Contains(t, request.Body, "| !4 |")
+
+	_, number, labels := fakeForge.AddLabelsArgsForCall(0)
+	assert.Equal(t, 5, number)
+	assert.Equal(t, []string{"label1", "label2"}, labels)
+
+	// nothing goes through the GitHub clients
+	assert.Equal(t, 0, f.issues.ListByRepoCallCount())
+	assert.Equal(t, 0, f.pulls.ListCallCount())
+	assert.Equal(t, 0, f.pulls.CreateCallCount())
+	assert.Equal(t, 0, f.git.CreateRefCallCount())
+}
+
+func TestBundlerForgeSquashesFileStates(t *testing.T) {
+	bundler, f := newTestBundler()
+	fakeForge := &forgeFakes.FakeForge{}
+	bundler.Forge = fakeForge
+	bundler.BotName = "dependabot"
+	fakeForge.ListChangeRequestsReturns([]*forge.ChangeRequest{
+		{Number: 4, SourceBranch: "dependabot-go_modules-.-github.com-test-a-1.1.0", State: forge.StateOpen},
+		{Number: 5, SourceBranch: "dependabot-go_modules-.-github.com-test-b-1.1.0", State: forge.StateOpen},
+	}, nil)
+	fakeForge.OpenChangeRequestReturns(&forge.ChangeRequest{Number: 6}, nil)
+	f.updater.UpdateReturnsOnCall(0, []providers.FileChange{
+		{Path: "testdata/script.sh", Kind: providers.Added},
+		{Path: "testdata/go.mod", Kind: providers.Added},
+	}, nil)
+	// the second update modifies the added script and deletes the other added file again
+	f.updater.UpdateReturnsOnCall(1, []providers.FileChange{
+		{Path: "testdata/script.sh", Kind: providers.Modified},
+		{Path: "testdata/go.mod", Kind: providers.Deleted},
+	}, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	require.Equal(t, 1, fakeForge.CommitFilesCallCount())
+	_, commit := fakeForge.CommitFilesArgsForCall(0)
+	assert.Equal(t, []forge.File{
+		{Path: "testdata/script.sh", Content: []byte("#!/bin/sh\necho \"test\"\n"), Executable: true, Added: true},
+	}, commit.Files)
+}
+
+func TestBundlerForgeSymlink(t *testing.T) {
+	bundler, f := newTestBundler()
+	fakeForge := &forgeFakes.FakeForge{}
+	bundler.Forge = fakeForge
+	bundler.BotName = "dependabot"
+	fakeForge.ListChangeRequestsReturns([]*forge.ChangeRequest{
+		{Number: 4, SourceBranch: "dependabot-go_modules-.-github.com-test-a-1.1.0", State: forge.StateOpen},
+	}, nil)
+	fakeForge.OpenChangeRequestReturns(&forge.ChangeRequest{Number: 5}, nil)
+
+	link := filepath.Join(t.TempDir(), "current")
+	require.NoError(t, os.Symlink("go.mod", link))
+	f.updater.UpdateReturns([]providers.FileChange{{Path: link, Kind: providers.Modified}}, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	// the forge decides whether it can commit the link
+	require.Equal(t, 1, fakeForge.CommitFilesCallCount())
+	_, commit := fakeForge.CommitFilesArgsForCall(0)
+	assert.Equal(t, []forge.File{{Path: link, Content: []byte("go.mod"), Symlink: true}}, commit.Files)
+}
+
+func TestBundlerGitHubForge(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.Forge = &forge.GitHub{
+		Owner:  "owner",
+		Repo:   "repo",
+		Issues: f.issues,
+		Pulls:  f.pulls,
+		Logger: &logger.QuiteLogger{},
+	}
+	bundler.CommentOnPRs = true
+
+	require.NoError(t, bundler.Bundle())
+
+	// the commits are created with the Git Data API like without a forge
+	assert.Equal(t, 1, f.pulls.ListCallCount())
+	assert.Equal(t, 1, f.git.CreateRefCallCount())
+	assert.Equal(t, 1, f.git.CreateTreeCallCount())
+	assert.Equal(t, 1, f.pulls.CreateCallCount())
+	assert.Equal(t, 1, f.issues.CreateCommentCallCount())
+}
+
+func TestBundlerForgeUnsupportedOptions(t *testing.T) {
+	bundler, _ := newTestBundler()
+	bundler.Forge = &forgeFakes.FakeForge{}
+	bundler.CommentOnPRs = true
+
+	assert.ErrorContains(t, bundler.Bundle(), "only supported on GitHub")
+}
+
+func TestBundlerRenovate(t *testing.T) {
+	bundler, f := newTestBundler()
+	f.issues.ListByRepoReturns([]*github.Issue{
+		{
+			Number: github.Int(1),
+			Body: github.String("| Package | Type | Update | Change | Package file |\n|---|---|---|---|---|\n" +
+				"| [golang.org/x/sys](https://togithub.com/golang/sys) | require | minor | `v0.14.0` -> `v0.15.0` | " +
+				"`go.mod` |\n"),
+			PullRequestLinks: &github.PullRequestLinks{},
+		},
+	}, &github.Response{}, nil)
+	f.pulls.GetReturns(&github.PullRequest{
+		Title: github.String("fix(deps): update module golang.org/x/sys to v0.15.0"),
+		Head:  &github.PullRequestBranch{Ref: github.String("renovate/golang.org-x-sys-0.x")},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	require.Equal(t, 1, f.updater.UpdateCallCount())
+	body, branch, title := f.updater.UpdateArgsForCall(0)
+	assert.Equal(t, "Bumps [golang.org/x/sys](golang.org/x/sys) from 0.14.0 to 0.15.0.", body)
+	assert.Equal(t, "dependabot/go_modules/golang.org/x/sys-0.15.0", branch)
+	assert.Equal(t, "Bump golang.org/x/sys from 0.14.0 to 0.15.0", title)
+
+	_, _, _, pr := f.pulls.CreateArgsForCall(0)
+	assert.Contains(t, pr.GetBody(), "| `golang.org/x/sys` | go_modules | `/` | `v0.14.0` → `v0.15.0` | minor | #1 |")
+}
+
+func TestBundlerRenovateSubdirectory(t *testing.T) {
+	bundler, f := newTestBundler()
+	f.issues.ListByRepoReturns([]*github.Issue{
+		{
+			Number: github.Int(1),
+			Body: github.String("| Package | Type | Update | Change | Package file |\n|---|---|---|---|---|\n" +
+				"| golang.org/x/sys | require | minor | `v0.14.0` -> `v0.15.0` | `hack/tools/go.mod` |\n"),
+			PullRequestLinks: &github.PullRequestLinks{},
+		},
+	}, &github.Response{}, nil)
+	f.pulls.GetReturns(&github.PullRequest{
+		Title: github.String("fix(deps): update module golang.org/x/sys to v0.15.0"),
+		Head:  &github.PullRequestBranch{Ref: github.String("renovate/golang.org-x-sys-0.x")},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	require.Equal(t, 1, f.updater.UpdateCallCount())
+	_, branch, title := f.updater.UpdateArgsForCall(0)
+	assert.Equal(t, "dependabot/go_modules/golang.org/x/sys-0.15.0", branch)
+	assert.Equal(t, "Bump golang.org/x/sys from 0.14.0 to 0.15.0 in /hack/tools", title)
+}
+
+func TestBundlerRenovateWithoutDirectory(t *testing.T) {
+	bundler, f := newTestBundler()
+	f.issues.ListByRepoReturns([]*github.Issue{
+		{
+			Number: github.Int(1),
+			// without the package file, the module of the update is unknown
+			Body: github.String("| Package | Type | Update | Change |\n|---|---|---|---|\n" +
+				"| golang.org/x/sys | require | minor | `v0.14.0` -> `v0.15.0` |\n"),
+			PullRequestLinks: &github.PullRequestLinks{},
+		},
+	}, &github.Response{}, nil)
+	f.pulls.GetReturns(&github.PullRequest{
+		Title: github.String("fix(deps): update module golang.org/x/sys to v0.15.0"),
+		Head:  &github.PullRequestBranch{Ref: github.String("renovate/golang.org-x-sys-0.x")},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	assert.Equal(t, 0, f.updater.UpdateCallCount())
+	assert.Equal(t, 0, f.pulls.CreateCallCount())
+}
+
+func TestBundlerRenovateTitleOnly(t *testing.T) {
+	bundler, f := newTestBundler()
+	f.issues.ListByRepoReturns([]*github.Issue{
+		{Number: github.Int(1), Body: github.String("no update table"), PullRequestLinks: &github.PullRequestLinks{}},
+	}, &github.Response{}, nil)
+	// the title names the dependency and the new version, but not the old one
+	f.pulls.GetReturns(&github.PullRequest{
+		Title: github.String("chore(deps): update actions/checkout action to v4"),
+		Head:  &github.PullRequestBranch{Ref: github.String("renovate/actions-checkout-4.x")},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	assert.Equal(t, 0, f.updater.UpdateCallCount())
+	assert.Equal(t, 0, f.pulls.CreateCallCount())
+}
+
+func TestBundlerGitBackendWithoutChanges(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.Backend = pkg.BackendGit
+	f.updater.UpdateReturns(nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	var commands [][]string
+
+	for i := 0; i < f.runner.RunCallCount(); i++ {
+		_, _, args := f.runner.RunArgsForCall(i)
+		commands = append(commands, gitArgs(args))
+	}
+
+	// skip creating and removing the worktree
+	commands = commands[2 : len(commands)-1]
+	require.Len(t, commands, 2)
+	// nothing is staged, git add without paths would stage the whole worktree
+	assert.Equal(t, []string{
+		"-c", "user.name=author", "-c", "user.email=author@git.com",
+		"commit", "-m", "Bundling updated dependencies.", "--allow-empty",
+	}, commands[0])
+	assert.Equal(t, "push", commands[1][0])
+	assert.Equal(t, 1, f.pulls.CreateCallCount())
 }
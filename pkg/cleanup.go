@@ -0,0 +1,161 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v43/github"
+)
+
+// CleanupOptions configures the removal of stale bundler branches and PRs.
+type CleanupOptions struct {
+	// MaxAge is the age after which a branch without a PR is deleted and an open bundle PR which has
+	// not been updated is closed. Zero disables the age based cleanup.
+	MaxAge time.Duration
+	// DryRun only logs what would be deleted.
+	DryRun bool
+}
+
+// Cleanup lists the branches created by the bundler, including the ones of groups with a custom branch prefix,
+// and deletes the ones which are no longer needed:
+//   - the PR of the branch was closed or merged
+//   - the branch has no PR and is older than MaxAge
+//   - the PR of the branch is still open, but hasn't been updated for MaxAge. The PR is closed as well.
+func (n *Bundler) Cleanup(opts CleanupOptions) error {
+	branches, err := n.listBranches()
+	if err != nil {
+		return err
+	}
+
+	n.Logger.Log("found %d bundler branches\n", len(branches))
+
+	now := time.Now().UTC()
+
+	for _, branch := range branches {
+		prs, _, err := n.Pulls.List(context.Background(), n.Owner, n.Repo, &github.PullRequestListOptions{
+			State: "all",
+			Head:  n.Owner + ":" + branch.name,
+		})
+		if err != nil {
+			n.Logger.Log("failed to list pull requests of branch %s, skipping: %s\n", branch.name, err)
+
+			continue
+		}
+
+		open, reason := n.staleReason(branch.created, prs, now, opts.MaxAge)
+		if reason == "" {
+			n.Logger.Debug("keeping branch %s\n", branch.name)
+
+			continue
+		}
+
+		if opts.DryRun {
+			n.Logger.Log("would delete branch %s: %s\n", branch.name, reason)
+
+			continue
+		}
+
+		n.Logger.Log("deleting branch %s: %s\n", branch.name, reason)
+
+		if open != nil {
+			if err := n.closePR(open.GetNumber()); err != nil {
+				n.Logger.Log("failed to close PR #%d, skipping: %s\n", open.GetNumber(), err)
+
+				continue
+			}
+		}
+
+		if err := n.deleteBranch(branch.name); err != nil {
+			n.Logger.Log("failed to delete branch %s: %s\n", branch.name, err)
+		}
+	}
+
+	return nil
+}
+
+// bundlerBranch is a branch created by the bundler.
+type bundlerBranch struct {
+	name    string
+	created time.Time
+}
+
+// listBranches returns the branches of the bundler and of the groups together with the time they were created
+// at. Branches which only share the prefix, like a `bundler-experiment` branch pushed by hand, are left out.
+func (n *Bundler) listBranches() ([]bundlerBranch, error) {
+	var branches []bundlerBranch
+
+	seen := make(map[string]struct{})
+
+	for _, prefix := range n.branchPrefixes() {
+		refs, _, err := n.Git.ListMatchingRefs(context.Background(), n.Owner, n.Repo, &github.ReferenceListOptions{
+			Ref: "heads/" + prefix,
+			ListOptions: github.ListOptions{
+				PerPage: defaultNumberOfItemsPerPage,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list refs: %w", err)
+		}
+
+		for _, ref := range refs {
+			branch := strings.TrimPrefix(ref.GetRef(), "refs/heads/")
+			if _, ok := seen[branch]; ok {
+				continue
+			}
+
+			seen[branch] = struct{}{}
+
+			created, ok := n.bundleBranch(branch)
+			if !ok {
+				n.Logger.Debug("ignoring branch %s which wasn't created by the bundler\n", branch)
+
+				continue
+			}
+
+			branches = append(branches, bundlerBranch{name: branch, created: created})
+		}
+	}
+
+	return branches, nil
+}
+
+// staleReason returns why the branch should be deleted, or an empty string if it should be kept. If the
+// branch has an open PR which has to be closed first, it is returned as well.
+func (n *Bundler) staleReason(
+	created time.Time,
+	prs []*github.PullRequest,
+	now time.Time,
+	maxAge time.Duration,
+) (*github.PullRequest, string) {
+	for _, pr := range prs {
+		if pr.GetState() != "open" {
+			continue
+		}
+
+		if maxAge > 0 && pr.UpdatedAt != nil && now.Sub(*pr.UpdatedAt) > maxAge {
+			return pr, fmt.Sprintf("PR #%d hasn't been updated for more than %s", pr.GetNumber(), maxAge)
+		}
+
+		return nil, ""
+	}
+
+	if len(prs) > 0 {
+		if prs[0].MergedAt != nil {
+			return nil, fmt.Sprintf("PR #%d was merged", prs[0].GetNumber())
+		}
+
+		return nil, fmt.Sprintf("PR #%d was closed", prs[0].GetNumber())
+	}
+
+	if maxAge == 0 {
+		return nil, ""
+	}
+
+	if now.Sub(created) > maxAge {
+		return nil, fmt.Sprintf("branch has no PR and is older than %s", maxAge)
+	}
+
+	return nil, ""
+}
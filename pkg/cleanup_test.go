@@ -0,0 +1,202 @@
+package pkg_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v43/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Skarlso/dependabot-bundler/pkg"
+	"github.com/Skarlso/dependabot-bundler/pkg/api/fakes"
+	"github.com/Skarlso/dependabot-bundler/pkg/logger"
+)
+
+func TestCleanup(t *testing.T) {
+	fakeGit := &fakes.FakeGit{}
+	fakePulls := &fakes.FakePullRequests{}
+	bundler := pkg.NewBundler(pkg.Config{
+		Owner:  "owner",
+		Repo:   "repo",
+		Git:    fakeGit,
+		Pulls:  fakePulls,
+		Logger: &logger.QuiteLogger{},
+	})
+
+	now := time.Now().UTC()
+	old := now.Add(-48 * time.Hour)
+	recent := now.Add(-time.Hour)
+	branches := map[string][]*github.PullRequest{
+		"bundler-1":                              {{Number: github.Int(1), State: github.String("closed"), MergedAt: &old}},
+		"bundler-2":                              {{Number: github.Int(2), State: github.String("closed")}},
+		"bundler-3":                              {{Number: github.Int(3), State: github.String("open"), UpdatedAt: &recent}},
+		"bundler-4":                              {{Number: github.Int(4), State: github.String("open"), UpdatedAt: &old}},
+		fmt.Sprintf("bundler-%d", old.Unix()):    nil,
+		fmt.Sprintf("bundler-%d", recent.Unix()): nil,
+	}
+
+	var refs []*github.Reference
+	for branch := range branches {
+		refs = append(refs, &github.Reference{Ref: github.String("refs/heads/" + branch)})
+	}
+
+	fakeGit.ListMatchingRefsReturns(refs, nil, nil)
+	fakePulls.ListCalls(func(_ context.Context, _, _ string, opts *github.PullRequestListOptions) (
+		[]*github.PullRequest, *github.Response, error,
+	) {
+		return branches[strings.TrimPrefix(opts.Head, "owner:")], nil, nil
+	})
+
+	require.NoError(t, bundler.Cleanup(pkg.CleanupOptions{MaxAge: 24 * time.Hour}))
+
+	_, _, _, opts := fakeGit.ListMatchingRefsArgsForCall(0)
+	assert.Equal(t, "heads/bundler-", opts.Ref)
+
+	var deleted []string
+
+	for i := 0; i < fakeGit.DeleteRefCallCount(); i++ {
+		_, _, _, ref := fakeGit.DeleteRefArgsForCall(i)
+		deleted = append(deleted, ref)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"refs/heads/bundler-1",
+		"refs/heads/bundler-2",
+		"refs/heads/bundler-4",
+		fmt.Sprintf("refs/heads/bundler-%d", old.Unix()),
+	}, deleted)
+
+	require.Equal(t, 1, fakePulls.EditCallCount())
+	_, _, _, number, pr := fakePulls.EditArgsForCall(0)
+	assert.Equal(t, 4, number)
+	assert.Equal(t, "closed", pr.GetState())
+}
+
+func TestCleanupDryRun(t *testing.T) {
+	fakeGit := &fakes.FakeGit{}
+	fakePulls := &fakes.FakePullRequests{}
+	bundler := pkg.NewBundler(pkg.Config{
+		Owner:  "owner",
+		Repo:   "repo",
+		Git:    fakeGit,
+		Pulls:  fakePulls,
+		Logger: &logger.QuiteLogger{},
+	})
+	fakeGit.ListMatchingRefsReturns([]*github.Reference{
+		{Ref: github.String("refs/heads/bundler-1")},
+	}, nil, nil)
+	fakePulls.ListReturns([]*github.PullRequest{
+		{Number: github.Int(1), State: github.String("closed")},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Cleanup(pkg.CleanupOptions{DryRun: true}))
+
+	assert.Equal(t, 0, fakeGit.DeleteRefCallCount())
+	assert.Equal(t, 0, fakePulls.EditCallCount())
+}
+
+func TestCleanupGroupBranches(t *testing.T) {
+	fakeGit := &fakes.FakeGit{}
+	fakePulls := &fakes.FakePullRequests{}
+	bundler := pkg.NewBundler(pkg.Config{
+		Owner: "owner",
+		Repo:  "repo",
+		Groups: []pkg.Group{
+			{Name: "go"},
+			{Name: "actions", Branch: "deps/actions-"},
+		},
+		Git:    fakeGit,
+		Pulls:  fakePulls,
+		Logger: &logger.QuiteLogger{},
+	})
+	fakeGit.ListMatchingRefsCalls(func(_ context.Context, _, _ string, opts *github.ReferenceListOptions) (
+		[]*github.Reference, *github.Response, error,
+	) {
+		if opts.Ref == "heads/deps/actions-" {
+			return []*github.Reference{{Ref: github.String("refs/heads/deps/actions-1")}}, nil, nil
+		}
+
+		return []*github.Reference{{Ref: github.String("refs/heads/bundler-go-1")}}, nil, nil
+	})
+	fakePulls.ListReturns([]*github.PullRequest{
+		{Number: github.Int(1), State: github.String("closed")},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Cleanup(pkg.CleanupOptions{}))
+
+	require.Equal(t, 2, fakeGit.ListMatchingRefsCallCount())
+	require.Equal(t, 2, fakeGit.DeleteRefCallCount())
+	_, _, _, ref := fakeGit.DeleteRefArgsForCall(0)
+	assert.Equal(t, "refs/heads/bundler-go-1", ref)
+	_, _, _, ref = fakeGit.DeleteRefArgsForCall(1)
+	assert.Equal(t, "refs/heads/deps/actions-1", ref)
+}
+
+func TestCleanupIgnoresForeignBranches(t *testing.T) {
+	fakeGit := &fakes.FakeGit{}
+	fakePulls := &fakes.FakePullRequests{}
+	bundler := pkg.NewBundler(pkg.Config{
+		Owner: "owner",
+		Repo:  "repo",
+		Groups: []pkg.Group{
+			{Name: "go"},
+			{Name: "actions", Branch: "deps/actions"},
+		},
+		Git:    fakeGit,
+		Pulls:  fakePulls,
+		Logger: &logger.QuiteLogger{},
+	})
+
+	old := time.Now().UTC().Add(-48 * time.Hour)
+	closed := []*github.PullRequest{{Number: github.Int(1), State: github.String("closed")}}
+	branches := map[string][]*github.PullRequest{
+		"bundler-experiment":                         closed,
+		"bundler-v-2":                                nil,
+		"bundler-go-":                                closed,
+		"bundler-go-experiment-1":                    closed,
+		"bundler-+1":                                 closed,
+		fmt.Sprintf("bundler-%d", old.Unix()):        nil,
+		fmt.Sprintf("bundler-go-%d", old.Unix()):     nil,
+		"deps/actions-experiment":                    closed,
+		fmt.Sprintf("deps/actions%d", old.Unix()):    nil,
+		fmt.Sprintf("deps/actions-v-%d", old.Unix()): nil,
+	}
+
+	fakeGit.ListMatchingRefsCalls(func(_ context.Context, _, _ string, opts *github.ReferenceListOptions) (
+		[]*github.Reference, *github.Response, error,
+	) {
+		var refs []*github.Reference
+
+		for branch := range branches {
+			if strings.HasPrefix(branch, strings.TrimPrefix(opts.Ref, "heads/")) {
+				refs = append(refs, &github.Reference{Ref: github.String("refs/heads/" + branch)})
+			}
+		}
+
+		return refs, nil, nil
+	})
+	fakePulls.ListCalls(func(_ context.Context, _, _ string, opts *github.PullRequestListOptions) (
+		[]*github.PullRequest, *github.Response, error,
+	) {
+		return branches[strings.TrimPrefix(opts.Head, "owner:")], nil, nil
+	})
+
+	require.NoError(t, bundler.Cleanup(pkg.CleanupOptions{MaxAge: 24 * time.Hour}))
+
+	var deleted []string
+
+	for i := 0; i < fakeGit.DeleteRefCallCount(); i++ {
+		_, _, _, ref := fakeGit.DeleteRefArgsForCall(i)
+		deleted = append(deleted, ref)
+	}
+
+	assert.ElementsMatch(t, []string{
+		fmt.Sprintf("refs/heads/bundler-%d", old.Unix()),
+		fmt.Sprintf("refs/heads/bundler-go-%d", old.Unix()),
+		fmt.Sprintf("refs/heads/deps/actions%d", old.Unix()),
+	}, deleted)
+}
@@ -0,0 +1,171 @@
+package pkg
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/metadata"
+	"github.com/Skarlso/dependabot-bundler/pkg/providers"
+	"github.com/google/go-github/v43/github"
+)
+
+// Git file modes used in tree entries.
+const (
+	modeFile       = "100644"
+	modeExecutable = "100755"
+	modeSymlink    = "120000"
+)
+
+// DefaultBlobThreshold is the size in bytes above which files are uploaded through the blobs API.
+const DefaultBlobThreshold = 512 * 1024
+
+// fileState is the state of a file right after an update.
+type fileState struct {
+	// content of the file. For symbolic links, this is the target of the link.
+	content []byte
+	mode    string
+	added   bool
+	deleted bool
+}
+
+// change contains the files modified by a single update.
+type change struct {
+	title  string
+	update metadata.Update
+	// files contains the state of each changed file right after the update.
+	files map[string]fileState
+}
+
+// commitSpec describes a commit to create.
+type commitSpec struct {
+	message string
+	files   map[string]fileState
+}
+
+// commits returns the commits to create for the changes. By default, that is a single commit containing
+// every change. With CommitPerDependency, each change gets its own commit which closes the original PR.
+func (n *Bundler) commits(changes []change, message string) []commitSpec {
+	if n.CommitPerDependency {
+		reference := n.forge().ReferencePrefix()
+
+		result := make([]commitSpec, 0, len(changes))
+		for _, c := range changes {
+			result = append(result, commitSpec{
+				message: fmt.Sprintf("%s\n\nCloses %s%d", c.title, reference, c.update.Number),
+				files:   c.files,
+			})
+		}
+
+		return result
+	}
+
+	files := make(map[string]fileState)
+
+	// later changes contain the most recent state of a file, but whether it exists on the base branch is
+	// decided by the first one
+	for _, c := range changes {
+		for file, state := range c.files {
+			previous, ok := files[file]
+
+			switch {
+			case ok && previous.added && state.deleted:
+				// the file didn't exist before and doesn't exist after the bundle
+				delete(files, file)
+
+				continue
+			case ok && previous.added:
+				state.added = true
+			case ok && previous.deleted:
+				state.added = false
+			}
+
+			files[file] = state
+		}
+	}
+
+	return []commitSpec{{message: message, files: files}}
+}
+
+// readFiles returns the state of each changed file.
+func readFiles(files []providers.FileChange) (map[string]fileState, error) {
+	states := make(map[string]fileState, len(files))
+
+	for _, file := range files {
+		if file.Kind == providers.Deleted {
+			states[file.Path] = fileState{deleted: true}
+
+			continue
+		}
+
+		state, err := readFile(file.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		state.added = file.Kind == providers.Added
+		states[file.Path] = state
+	}
+
+	return states, nil
+}
+
+// readFile returns the content and the git mode of a file.
+func readFile(path string) (fileState, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return fileState{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return fileState{}, fmt.Errorf("failed to read link: %w", err)
+		}
+
+		return fileState{content: []byte(target), mode: modeSymlink}, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fileState{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	mode := modeFile
+	if info.Mode()&0o111 != 0 {
+		mode = modeExecutable
+	}
+
+	return fileState{content: content, mode: mode}, nil
+}
+
+// needsBlob returns whether the content of the file has to be uploaded as a blob. Inlining content into
+// a tree only works for text and is limited by the size of the request, so large and binary files are
+// uploaded separately.
+func (n *Bundler) needsBlob(state fileState) bool {
+	if state.mode == modeSymlink {
+		return false
+	}
+
+	threshold := n.BlobThreshold
+	if threshold <= 0 {
+		threshold = DefaultBlobThreshold
+	}
+
+	return len(state.content) > threshold || !utf8.Valid(state.content)
+}
+
+// createBlob uploads the content base64 encoded and returns the SHA of the created blob.
+func (n *Bundler) createBlob(content []byte) (string, error) {
+	blob, _, err := n.Git.CreateBlob(context.Background(), n.Owner, n.Repo, &github.Blob{
+		Content:  github.String(base64.StdEncoding.EncodeToString(content)),
+		Encoding: github.String("base64"),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create blob: %w", err)
+	}
+
+	return blob.GetSHA(), nil
+}
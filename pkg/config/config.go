@@ -0,0 +1,163 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Skarlso/dependabot-bundler/pkg"
+	"github.com/Skarlso/dependabot-bundler/pkg/metadata"
+)
+
+// DefaultPath is the location of the config file in the repository.
+const DefaultPath = ".github/bundler.yml"
+
+// Version is the supported version of the config file.
+const Version = 1
+
+// File is the content of the config file of the bundler. Empty values are left to the command line flags.
+type File struct {
+	// Version of the config file, must be Version.
+	Version                int         `yaml:"version"`
+	TargetBranch           string      `yaml:"target-branch"`
+	Labels                 []string    `yaml:"labels"`
+	BotName                string      `yaml:"bot-name"`
+	Bots                   []pkg.Bot   `yaml:"bots"`
+	Author                 Author      `yaml:"author"`
+	PRTitle                string      `yaml:"pr-title"`
+	CloseSuperseded        string      `yaml:"close-superseded"`
+	CommentOnPRs           *bool       `yaml:"comment-on-prs"`
+	VerifyBots             *bool       `yaml:"verify-bots"`
+	RequireVerifiedCommits *bool       `yaml:"require-verified-commits"`
+	CommitPerDependency    *bool       `yaml:"commit-per-dependency"`
+	BlobThreshold          int         `yaml:"blob-threshold"`
+	Backend                string      `yaml:"backend"`
+	KeepOnFailure          *bool       `yaml:"keep-on-failure"`
+	Templates              Templates   `yaml:"templates"`
+	Filter                 pkg.Filter  `yaml:"filter"`
+	MaxUpdateLevel         string      `yaml:"max-update-level"`
+	ExcludeMajor           *bool       `yaml:"exclude-major"`
+	Groups                 []pkg.Group `yaml:"groups"`
+	Signing                Signing     `yaml:"signing"`
+	UseDependabotConfig    *bool       `yaml:"use-dependabot-config"`
+}
+
+// Author is the author of the bundle commits.
+type Author struct {
+	Name  string `yaml:"name"`
+	Email string `yaml:"email"`
+}
+
+// Templates contains the paths of the template files, relative to the root of the repository.
+type Templates struct {
+	PRTitle       string `yaml:"pr-title"`
+	PRBody        string `yaml:"pr-body"`
+	CommitMessage string `yaml:"commit-message"`
+}
+
+// Signing contains the signing options. The keys themselves are secrets and can only be passed as flags.
+type Signing struct {
+	Name      string `yaml:"name"`
+	Email     string `yaml:"email"`
+	BitLength int    `yaml:"bit-length"`
+	// GitKey is the ID of the GPG key the git backend signs the commits with.
+	GitKey string `yaml:"git-key"`
+}
+
+// Load reads and validates the config file at path.
+func Load(path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	file, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	return file, nil
+}
+
+// Parse decodes and validates the content of a config file. Unknown fields are rejected.
+func Parse(content []byte) (*File, error) {
+	file := &File{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Validate checks the values of the config file. The errors name the offending field.
+func (f *File) Validate() error {
+	if f.Version != Version {
+		return fmt.Errorf("version: must be %d, got %d", Version, f.Version)
+	}
+
+	switch f.CloseSuperseded {
+	case pkg.CloseNever, pkg.CloseOnOpen, pkg.CloseOnMerge:
+	default:
+		return fmt.Errorf("close-superseded: must be %s or %s, got %s", pkg.CloseOnOpen, pkg.CloseOnMerge, f.CloseSuperseded)
+	}
+
+	switch f.Backend {
+	case "", pkg.BackendAPI, pkg.BackendGit:
+	default:
+		return fmt.Errorf("backend: must be %s or %s, got %s", pkg.BackendAPI, pkg.BackendGit, f.Backend)
+	}
+
+	if f.BlobThreshold < 0 {
+		return fmt.Errorf("blob-threshold: must not be negative, got %d", f.BlobThreshold)
+	}
+
+	if f.Signing.BitLength < 0 {
+		return fmt.Errorf("signing.bit-length: must not be negative, got %d", f.Signing.BitLength)
+	}
+
+	if err := metadata.ValidateLevel(f.MaxUpdateLevel); err != nil {
+		return fmt.Errorf("max-update-level: %w", err)
+	}
+
+	if err := f.Filter.Validate(); err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+
+	for i, bot := range f.Bots {
+		if err := bot.Validate(); err != nil {
+			return fmt.Errorf("bots[%d]: %w", i, err)
+		}
+	}
+
+	names := make(map[string]int, len(f.Groups))
+
+	for i, group := range f.Groups {
+		if err := group.Validate(); err != nil {
+			return fmt.Errorf("groups[%d]: %w", i, err)
+		}
+
+		if err := metadata.ValidateLevel(group.MaxUpdateLevel); err != nil {
+			return fmt.Errorf("groups[%d].max-update-level: %w", i, err)
+		}
+
+		if other, ok := names[group.Name]; ok {
+			return fmt.Errorf("groups[%d].name: %s is already used by groups[%d]", i, group.Name, other)
+		}
+
+		names[group.Name] = i
+	}
+
+	return nil
+}
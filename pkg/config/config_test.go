@@ -0,0 +1,153 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Skarlso/dependabot-bundler/pkg"
+)
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundler.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`version: 1
+target-branch: develop
+labels: [dependencies]
+bots:
+  - name: app/dependabot
+  - name: app/renovate
+    parser: renovate
+    id: 29139614
+author:
+  name: bundler
+close-superseded: on-merge
+comment-on-prs: false
+require-verified-commits: true
+templates:
+  pr-body: .github/bundle.tmpl
+filter:
+  exclude-dependencies: ["k8s.io/*"]
+groups:
+  - name: actions
+    title: Bundled action updates
+    filter:
+      include-ecosystems: [github_actions]
+  - name: go
+    max-update-level: minor
+signing:
+  git-key: ABCDEF
+`), 0o600))
+
+	file, err := Load(path)
+	require.NoError(t, err)
+
+	commentOnPRs, verifiedCommits := false, true
+	assert.Equal(t, &File{
+		Version:                1,
+		TargetBranch:           "develop",
+		Labels:                 []string{"dependencies"},
+		Bots:                   []pkg.Bot{{Name: "app/dependabot"}, {Name: "app/renovate", Parser: "renovate", ID: 29139614}},
+		Author:                 Author{Name: "bundler"},
+		CloseSuperseded:        pkg.CloseOnMerge,
+		CommentOnPRs:           &commentOnPRs,
+		RequireVerifiedCommits: &verifiedCommits,
+		Templates:              Templates{PRBody: ".github/bundle.tmpl"},
+		Filter:                 pkg.Filter{ExcludeDependencies: []string{"k8s.io/*"}},
+		Groups: []pkg.Group{
+			{
+				Name:    "actions",
+				PRTitle: "Bundled action updates",
+				Filter:  pkg.Filter{IncludeEcosystems: []string{"github_actions"}},
+			},
+			{
+				Name:           "go",
+				MaxUpdateLevel: "minor",
+			},
+		},
+		Signing: Signing{GitKey: "ABCDEF"},
+	}, file)
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "bundler.yml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestParseInvalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "empty",
+			content: "",
+			err:     "version: must be 1, got 0",
+		},
+		{
+			name:    "unsupported version",
+			content: "version: 2",
+			err:     "version: must be 1, got 2",
+		},
+		{
+			name:    "unknown field",
+			content: "version: 1\ntarget: main",
+			err:     "line 2: field target not found",
+		},
+		{
+			name:    "wrong type",
+			content: "version: 1\nlabels: dependencies",
+			err:     "line 2: cannot unmarshal !!str `depende...` into []string",
+		},
+		{
+			name:    "close mode",
+			content: "version: 1\nclose-superseded: always",
+			err:     "close-superseded: must be on-open or on-merge, got always",
+		},
+		{
+			name:    "backend",
+			content: "version: 1\nbackend: svn",
+			err:     "backend: must be api or git, got svn",
+		},
+		{
+			name:    "level",
+			content: "version: 1\nmax-update-level: huge",
+			err:     "max-update-level: must be patch, minor or major, got huge",
+		},
+		{
+			name:    "filter",
+			content: "version: 1\nfilter:\n  include-dependencies: [\"re:(\"]",
+			err:     "filter: invalid regular expression re:(",
+		},
+		{
+			name:    "group name",
+			content: "version: 1\ngroups:\n  - title: no name",
+			err:     `groups[0]: invalid group name ""`,
+		},
+		{
+			name:    "group level",
+			content: "version: 1\ngroups:\n  - name: go\n    max-update-level: huge",
+			err:     "groups[0].max-update-level: must be patch, minor or major, got huge",
+		},
+		{
+			name:    "duplicate group",
+			content: "version: 1\ngroups:\n  - name: go\n  - name: go",
+			err:     "groups[1].name: go is already used by groups[0]",
+		},
+		{
+			name:    "bot parser",
+			content: "version: 1\nbots:\n  - name: app/dependabot\n    parser: snyk",
+			err:     "bots[0]: unknown parser snyk of bot app/dependabot, must be dependabot or renovate",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.content))
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
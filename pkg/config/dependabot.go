@@ -0,0 +1,207 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Skarlso/dependabot-bundler/pkg"
+	"github.com/Skarlso/dependabot-bundler/pkg/logger"
+	"github.com/Skarlso/dependabot-bundler/pkg/metadata"
+)
+
+// DefaultDependabotPath is the location of the Dependabot configuration in the repository.
+const DefaultDependabotPath = ".github/dependabot.yml"
+
+// ecosystems maps the package-ecosystem values of dependabot.yml to the names used in the branches of Dependabot.
+var ecosystems = map[string]string{
+	"gomod":          "go_modules",
+	"github-actions": "github_actions",
+	"npm":            "npm_and_yarn",
+	"mix":            "hex",
+	"gitsubmodule":   "submodules",
+}
+
+// invalidGroupChars matches the characters which aren't allowed in the names of bundle groups.
+var invalidGroupChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Dependabot is the part of dependabot.yml the bundler uses. Other fields are ignored.
+type Dependabot struct {
+	Version int                `yaml:"version"`
+	Updates []DependabotUpdate `yaml:"updates"`
+}
+
+// DependabotUpdate is an entry of the updates list of dependabot.yml.
+type DependabotUpdate struct {
+	PackageEcosystem string           `yaml:"package-ecosystem"`
+	Directory        string           `yaml:"directory"`
+	Directories      []string         `yaml:"directories"`
+	TargetBranch     string           `yaml:"target-branch"`
+	Groups           DependabotGroups `yaml:"groups"`
+}
+
+// DependabotGroup is a group of an update entry of dependabot.yml.
+type DependabotGroup struct {
+	Name            string   `yaml:"-"`
+	Patterns        []string `yaml:"patterns"`
+	ExcludePatterns []string `yaml:"exclude-patterns"`
+	UpdateTypes     []string `yaml:"update-types"`
+}
+
+// DependabotGroups keeps the groups in the order of the file, because an update belongs to the first
+// group it matches.
+type DependabotGroups []DependabotGroup
+
+// UnmarshalYAML decodes the mapping of group names to groups.
+func (g *DependabotGroups) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: groups must be a mapping", node.Line)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		group := DependabotGroup{Name: node.Content[i].Value}
+		if err := node.Content[i+1].Decode(&group); err != nil {
+			return err
+		}
+
+		*g = append(*g, group)
+	}
+
+	return nil
+}
+
+// LoadDependabot reads the Dependabot configuration at path.
+func LoadDependabot(path string) (*Dependabot, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dependabot config: %w", err)
+	}
+
+	dependabot := &Dependabot{}
+	if err := yaml.Unmarshal(content, dependabot); err != nil {
+		return nil, fmt.Errorf("invalid dependabot config %s: %w", path, err)
+	}
+
+	if dependabot.Version != 2 {
+		return nil, fmt.Errorf("invalid dependabot config %s: version: must be 2, got %d", path, dependabot.Version)
+	}
+
+	for i, update := range dependabot.Updates {
+		if update.PackageEcosystem == "" {
+			return nil, fmt.Errorf("invalid dependabot config %s: updates[%d].package-ecosystem: missing", path, i)
+		}
+	}
+
+	return dependabot, nil
+}
+
+// Ecosystem returns the name Dependabot uses in its branches for a package-ecosystem of dependabot.yml.
+func Ecosystem(name string) string {
+	if ecosystem, ok := ecosystems[name]; ok {
+		return ecosystem
+	}
+
+	return name
+}
+
+// Declarations returns the declared ecosystem and directory pairs.
+func (d *Dependabot) Declarations() []pkg.Declaration {
+	var result []pkg.Declaration
+
+	for _, update := range d.Updates {
+		for _, directory := range update.directories() {
+			result = append(result, pkg.Declaration{
+				Ecosystem: Ecosystem(update.PackageEcosystem),
+				Directory: directory,
+			})
+		}
+	}
+
+	return result
+}
+
+// Groups returns a bundle group for each group of dependabot.yml. If a name is used in several update entries,
+// the ecosystem is added to it. Groups whose name has no character which can be used in a branch name are
+// skipped with a warning, their updates are left to the other groups.
+func (d *Dependabot) Groups(log logger.Logger) []pkg.Group {
+	var (
+		result []pkg.Group
+		names  = make(map[string]struct{})
+	)
+
+	for _, update := range d.Updates {
+		ecosystem := Ecosystem(update.PackageEcosystem)
+
+		for _, group := range update.Groups {
+			name := strings.Trim(invalidGroupChars.ReplaceAllString(group.Name, "-"), "-")
+			if name == "" {
+				log.Log("skipping group %q of the Dependabot configuration, its name can't be used in a branch name\n",
+					group.Name)
+
+				continue
+			}
+
+			if _, ok := names[name]; ok {
+				name += "-" + ecosystem
+			}
+
+			names[name] = struct{}{}
+
+			result = append(result, pkg.Group{
+				Name: name,
+				Filter: pkg.Filter{
+					IncludeEcosystems:   []string{ecosystem},
+					IncludeDirectories:  update.directories(),
+					IncludeDependencies: group.Patterns,
+					ExcludeDependencies: group.ExcludePatterns,
+				},
+				MaxUpdateLevel: maxUpdateType(group.UpdateTypes),
+			})
+		}
+	}
+
+	return result
+}
+
+// TargetBranch returns the first target-branch of the update entries, or an empty string if there is none.
+func (d *Dependabot) TargetBranch() string {
+	for _, update := range d.Updates {
+		if update.TargetBranch != "" {
+			return update.TargetBranch
+		}
+	}
+
+	return ""
+}
+
+// directories returns the normalized directories of the entry, which may be globs.
+func (u DependabotUpdate) directories() []string {
+	directories := u.Directories
+	if u.Directory != "" {
+		directories = append([]string{u.Directory}, directories...)
+	}
+
+	result := make([]string, 0, len(directories))
+	for _, directory := range directories {
+		result = append(result, pkg.NormalizeDirectory(directory))
+	}
+
+	return result
+}
+
+// maxUpdateType returns the highest of the update-types of a group, or an empty string if none are set.
+func maxUpdateType(types []string) string {
+	var result string
+
+	for _, t := range types {
+		level := strings.TrimPrefix(t, "semver-")
+		if result == "" || metadata.Exceeds(level, result) {
+			result = level
+		}
+	}
+
+	return result
+}
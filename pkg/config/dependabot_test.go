@@ -0,0 +1,124 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Skarlso/dependabot-bundler/pkg"
+	"github.com/Skarlso/dependabot-bundler/pkg/logger"
+)
+
+const dependabotConfig = `version: 2
+updates:
+  - package-ecosystem: gomod
+    directory: "/"
+    schedule:
+      interval: weekly
+    groups:
+      aws:
+        patterns: ["github.com/aws/*"]
+        update-types: [patch, minor]
+      other:
+        patterns: ["*"]
+        exclude-patterns: ["k8s.io/*"]
+  - package-ecosystem: github-actions
+    directories: ["/", "/.github/actions/*/"]
+    target-branch: develop
+    groups:
+      other:
+        patterns: ["*"]
+  - package-ecosystem: docker
+    directory: tools/
+`
+
+func writeDependabot(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "dependabot.yml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoadDependabot(t *testing.T) {
+	dependabot, err := LoadDependabot(writeDependabot(t, dependabotConfig))
+	require.NoError(t, err)
+
+	assert.Equal(t, []pkg.Declaration{
+		{Ecosystem: "go_modules", Directory: "/"},
+		{Ecosystem: "github_actions", Directory: "/"},
+		{Ecosystem: "github_actions", Directory: "/.github/actions/*"},
+		{Ecosystem: "docker", Directory: "/tools"},
+	}, dependabot.Declarations())
+
+	assert.Equal(t, []pkg.Group{
+		{
+			Name: "aws",
+			Filter: pkg.Filter{
+				IncludeEcosystems:   []string{"go_modules"},
+				IncludeDirectories:  []string{"/"},
+				IncludeDependencies: []string{"github.com/aws/*"},
+			},
+			MaxUpdateLevel: "minor",
+		},
+		{
+			Name: "other",
+			Filter: pkg.Filter{
+				IncludeEcosystems:   []string{"go_modules"},
+				IncludeDirectories:  []string{"/"},
+				IncludeDependencies: []string{"*"},
+				ExcludeDependencies: []string{"k8s.io/*"},
+			},
+		},
+		{
+			Name: "other-github_actions",
+			Filter: pkg.Filter{
+				IncludeEcosystems:   []string{"github_actions"},
+				IncludeDirectories:  []string{"/", "/.github/actions/*"},
+				IncludeDependencies: []string{"*"},
+			},
+		},
+	}, dependabot.Groups(&logger.QuiteLogger{}))
+
+	assert.Equal(t, "develop", dependabot.TargetBranch())
+}
+
+func TestDependabotGroupsWithoutValidName(t *testing.T) {
+	dependabot, err := LoadDependabot(writeDependabot(t, `version: 2
+updates:
+  - package-ecosystem: gomod
+    directory: /
+    groups:
+      "🚀":
+        patterns: ["*"]
+      go:
+        patterns: ["golang.org/*"]
+`))
+	require.NoError(t, err)
+
+	groups := dependabot.Groups(&logger.QuiteLogger{})
+	require.Len(t, groups, 1)
+	assert.Equal(t, "go", groups[0].Name)
+}
+
+func TestLoadDependabotInvalid(t *testing.T) {
+	_, err := LoadDependabot(writeDependabot(t, "version: 1\n"))
+	assert.ErrorContains(t, err, "version: must be 2, got 1")
+
+	_, err = LoadDependabot(writeDependabot(t, "version: 2\nupdates:\n  - directory: /\n"))
+	assert.ErrorContains(t, err, "updates[0].package-ecosystem: missing")
+
+	_, err = LoadDependabot(writeDependabot(t, "version: 2\nupdates:\n  - package-ecosystem: npm\n    groups: [a]\n"))
+	assert.ErrorContains(t, err, "line 4: groups must be a mapping")
+}
+
+func TestEcosystem(t *testing.T) {
+	assert.Equal(t, "go_modules", Ecosystem("gomod"))
+	assert.Equal(t, "github_actions", Ecosystem("github-actions"))
+	assert.Equal(t, "npm_and_yarn", Ecosystem("npm"))
+	assert.Equal(t, "pip", Ecosystem("pip"))
+}
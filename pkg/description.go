@@ -0,0 +1,105 @@
+package pkg
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/metadata"
+)
+
+// otherEcosystem is used for updates of which the ecosystem could not be determined.
+const otherEcosystem = "other"
+
+// description renders the body of the bundle PR. The updates are shown in a table per ecosystem. Updates which
+// were excluded because of their level are listed in a separate table. The original PRs are linked with the
+// reference prefix of the forge.
+func description(updates, excluded []metadata.Update, reference string) string {
+	groups := make(map[string][]metadata.Update)
+
+	for _, u := range updates {
+		ecosystem := u.Ecosystem
+		if ecosystem == "" {
+			ecosystem = otherEcosystem
+		}
+
+		groups[ecosystem] = append(groups[ecosystem], u)
+	}
+
+	ecosystems := make([]string, 0, len(groups))
+	for ecosystem := range groups {
+		ecosystems = append(ecosystems, ecosystem)
+	}
+
+	sort.Strings(ecosystems)
+
+	var b strings.Builder
+
+	if len(updates) == 1 {
+		b.WriteString("Contains the following update:\n")
+	} else {
+		fmt.Fprintf(&b, "Contains the following %d updates:\n", len(updates))
+	}
+
+	for _, ecosystem := range ecosystems {
+		group := groups[ecosystem]
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].Number < group[j].Number
+		})
+
+		fmt.Fprintf(&b, "\n### %s\n\n", ecosystem)
+		writeTable(&b, group, reference)
+	}
+
+	if len(excluded) > 0 {
+		b.WriteString("\n### Excluded\n\n")
+		b.WriteString("The following updates exceed the maximum update level and need to be reviewed separately:\n\n")
+		writeTable(&b, excluded, reference)
+	}
+
+	return b.String()
+}
+
+// writeTable writes the updates as a markdown table.
+func writeTable(b *strings.Builder, updates []metadata.Update, reference string) {
+	b.WriteString("| Dependency | Ecosystem | Directory | From → To | Level | PR |\n")
+	b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+
+	for _, u := range updates {
+		fmt.Fprintf(b, "| %s | %s | %s | %s | %s | %s%d |\n",
+			cell(u.Dependency, true), cell(u.Ecosystem, false), cell(u.Directory, true),
+			versionCell(u.From, u.To), cell(u.Level(), false), reference, u.Number)
+	}
+}
+
+func versionCell(from, to string) string {
+	if from == "" && to == "" {
+		return "-"
+	}
+
+	return fmt.Sprintf("%s → %s", cell(from, true), cell(to, true))
+}
+
+// cell formats a table cell value. Empty values are replaced with a dash.
+func cell(value string, code bool) string {
+	if value == "" {
+		return "-"
+	}
+
+	value = strings.ReplaceAll(value, "|", `\|`)
+	if code {
+		return "`" + value + "`"
+	}
+
+	return value
+}
+
+// numbers returns the PR numbers of the updates.
+func numbers(updates []metadata.Update) []int {
+	result := make([]int, 0, len(updates))
+	for _, u := range updates {
+		result = append(result, u.Number)
+	}
+
+	return result
+}
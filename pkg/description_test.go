@@ -0,0 +1,113 @@
+package pkg
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/metadata"
+)
+
+var update = flag.Bool("update", false, "update the golden files of this test")
+
+// assertGolden compares the content with the given golden file in testdata. Run the tests
+// with `-update` to regenerate the golden files.
+func assertGolden(t *testing.T, name, content string) {
+	t.Helper()
+
+	golden := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.WriteFile(golden, []byte(content), 0o600))
+	}
+
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), content)
+}
+
+func TestDescription(t *testing.T) {
+	updates := []metadata.Update{
+		{
+			Number:     12,
+			Dependency: "golang.org/x/sys",
+			Ecosystem:  "go_modules",
+			Directory:  "/hack/tools",
+			From:       "0.0.0-20211013075003-97ac67df715c",
+			To:         "0.1.0",
+		},
+		{
+			Number:     3,
+			Dependency: "actions/checkout",
+			Ecosystem:  "github_actions",
+			Directory:  "/",
+			From:       "2",
+			To:         "3",
+		},
+		{
+			Number:     5,
+			Dependency: "github.com/aws/aws-sdk-go-v2/service/ssm",
+			Ecosystem:  "go_modules",
+			Directory:  "/",
+			From:       "1.26.0",
+			To:         "1.26.1",
+		},
+		{
+			Number:     7,
+			Dependency: "lycheeverse/lychee-action",
+			Ecosystem:  "github_actions",
+			Directory:  "/",
+			From:       "c0d1093b783f7ad0c445884b01da0215b2da29ee",
+			To:         "1.5.0",
+		},
+		{
+			Number:    9,
+			Directory: "/",
+		},
+	}
+
+	assertGolden(t, "description.golden", description(updates, nil, "#"))
+}
+
+func TestDescriptionSingleUpdate(t *testing.T) {
+	updates := []metadata.Update{
+		{
+			Number:     1,
+			Dependency: "github.com/test/test",
+			Ecosystem:  "go_modules",
+			Directory:  "/",
+			From:       "1.0.0",
+			To:         "1.1.0",
+		},
+	}
+
+	assertGolden(t, "description_single.golden", description(updates, nil, "#"))
+}
+
+func TestDescriptionExcluded(t *testing.T) {
+	updates := []metadata.Update{
+		{
+			Number:     1,
+			Dependency: "github.com/test/test",
+			Ecosystem:  "go_modules",
+			Directory:  "/",
+			From:       "1.0.0",
+			To:         "1.1.0",
+		},
+	}
+	excluded := []metadata.Update{
+		{
+			Number:     2,
+			Dependency: "actions/checkout",
+			Ecosystem:  "github_actions",
+			Directory:  "/",
+			From:       "3",
+			To:         "4",
+		},
+	}
+
+	assertGolden(t, "description_excluded.golden", description(updates, excluded, "#"))
+}
@@ -0,0 +1,230 @@
+package pkg
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/metadata"
+)
+
+// Filter decides which pull requests are bundled. Every include list which is set has to match
+// and none of the exclude lists may match.
+//
+// Directories and dependencies are matched with globs, where `*` matches any number of characters,
+// including `/`, and `?` matches a single character. Leading and trailing slashes of directory globs are
+// ignored. A pattern prefixed with `re:`, such as `re:^k8s\.io/`, is used as a regular expression instead.
+type Filter struct {
+	IncludeEcosystems   []string `yaml:"include-ecosystems"`
+	ExcludeEcosystems   []string `yaml:"exclude-ecosystems"`
+	IncludeDirectories  []string `yaml:"include-directories"`
+	ExcludeDirectories  []string `yaml:"exclude-directories"`
+	IncludeDependencies []string `yaml:"include-dependencies"`
+	ExcludeDependencies []string `yaml:"exclude-dependencies"`
+	// IncludeLabels requires the PR to have at least one of the labels.
+	IncludeLabels []string `yaml:"include-labels"`
+	ExcludeLabels []string `yaml:"exclude-labels"`
+}
+
+// regexpPrefix marks a pattern as a regular expression. Slashes can't be used for that, because directories
+// start and often end with one.
+const regexpPrefix = "re:"
+
+// Declaration is an ecosystem and directory pair for which updates are expected, as declared in dependabot.yml.
+type Declaration struct {
+	// Ecosystem is the name as it appears in the branch name, for example go_modules.
+	Ecosystem string
+	// Directory is a glob of the directories of the manifests.
+	Directory string
+}
+
+// NormalizeDirectory returns the directory with a leading and without a trailing slash.
+func NormalizeDirectory(directory string) string {
+	return "/" + strings.Trim(directory, "/")
+}
+
+// Validate checks that all patterns of the filter compile.
+func (f Filter) Validate() error {
+	for _, patterns := range [][]string{
+		f.IncludeDirectories, f.ExcludeDirectories, f.IncludeDependencies, f.ExcludeDependencies,
+	} {
+		for _, pattern := range patterns {
+			if _, err := compilePattern(pattern); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Match returns whether the update with the given labels passes the filter. If not, the reason is returned.
+func (f Filter) Match(update metadata.Update, labels []string) (bool, string) {
+	if len(f.IncludeEcosystems) > 0 && !contains(f.IncludeEcosystems, update.Ecosystem) {
+		return false, fmt.Sprintf("ecosystem %q is not included", update.Ecosystem)
+	}
+
+	if contains(f.ExcludeEcosystems, update.Ecosystem) {
+		return false, fmt.Sprintf("ecosystem %q is excluded", update.Ecosystem)
+	}
+
+	if len(f.IncludeDirectories) > 0 && !matchDirectory(f.IncludeDirectories, update.Directory) {
+		return false, fmt.Sprintf("directory %q is not included", update.Directory)
+	}
+
+	if matchDirectory(f.ExcludeDirectories, update.Directory) {
+		return false, fmt.Sprintf("directory %q is excluded", update.Directory)
+	}
+
+	if len(f.IncludeDependencies) > 0 && !matchAny(f.IncludeDependencies, update.Dependency) {
+		return false, fmt.Sprintf("dependency %q is not included", update.Dependency)
+	}
+
+	if matchAny(f.ExcludeDependencies, update.Dependency) {
+		return false, fmt.Sprintf("dependency %q is excluded", update.Dependency)
+	}
+
+	if len(f.IncludeLabels) > 0 && !containsAny(f.IncludeLabels, labels) {
+		return false, fmt.Sprintf("none of the labels %v is present", f.IncludeLabels)
+	}
+
+	for _, label := range labels {
+		if contains(f.ExcludeLabels, label) {
+			return false, fmt.Sprintf("label %q is excluded", label)
+		}
+	}
+
+	return true, ""
+}
+
+// filterCandidates drops the candidates which don't pass the filter and logs why.
+func (n *Bundler) filterCandidates(candidates []candidate) []candidate {
+	var result []candidate
+
+	for _, c := range candidates {
+		if !c.complete() {
+			n.Logger.Log("skipping PR #%d: failed to parse the dependency, the versions or the directory of the update\n",
+				c.update.Number)
+
+			continue
+		}
+
+		if ok, reason := n.Filter.Match(c.update, c.labels); !ok {
+			n.Logger.Log("skipping PR #%d: %s\n", c.update.Number, reason)
+
+			continue
+		}
+
+		if !n.declared(c.update) {
+			n.Logger.Log("skipping PR #%d: ecosystem %q in directory %q is not declared in the dependabot config\n",
+				c.update.Number, c.update.Ecosystem, c.update.Directory)
+
+			continue
+		}
+
+		result = append(result, c)
+	}
+
+	return result
+}
+
+// declared returns whether the ecosystem and directory of the update are declared. Without declarations,
+// every update is accepted.
+func (n *Bundler) declared(update metadata.Update) bool {
+	if len(n.Declared) == 0 {
+		return true
+	}
+
+	directory := NormalizeDirectory(update.Directory)
+
+	for _, d := range n.Declared {
+		if d.Ecosystem == update.Ecosystem && matchAny([]string{d.Directory}, directory) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compilePattern turns a glob or a regular expression prefixed with regexpPrefix into a regular expression.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if expr, ok := strings.CutPrefix(pattern, regexpPrefix); ok {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %s: %w", pattern, err)
+		}
+
+		return re, nil
+	}
+
+	var b strings.Builder
+
+	b.WriteString("^")
+
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
+
+// matchAny returns whether any of the patterns matches the value. Invalid patterns never match.
+func matchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		re, err := compilePattern(pattern)
+		if err != nil {
+			continue
+		}
+
+		if re.MatchString(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchDirectory returns whether any of the patterns matches the directory. Globs are normalized like the
+// directory, so `/tools/` matches `/tools`.
+func matchDirectory(patterns []string, directory string) bool {
+	normalized := make([]string, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		if !strings.HasPrefix(pattern, regexpPrefix) {
+			pattern = NormalizeDirectory(pattern)
+		}
+
+		normalized = append(normalized, pattern)
+	}
+
+	return matchAny(normalized, NormalizeDirectory(directory))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAny(values []string, others []string) bool {
+	for _, other := range others {
+		if contains(values, other) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,281 @@
+package pkg_test
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v43/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Skarlso/dependabot-bundler/pkg"
+	"github.com/Skarlso/dependabot-bundler/pkg/metadata"
+)
+
+func TestFilterMatch(t *testing.T) {
+	update := metadata.Update{
+		Number:     1,
+		Dependency: "k8s.io/api",
+		Ecosystem:  "go_modules",
+		Directory:  "/tools",
+	}
+
+	testCases := []struct {
+		name   string
+		filter pkg.Filter
+		labels []string
+		match  bool
+		reason string
+	}{
+		{
+			name:  "empty filter",
+			match: true,
+		},
+		{
+			name:   "included ecosystem",
+			filter: pkg.Filter{IncludeEcosystems: []string{"go_modules"}},
+			match:  true,
+		},
+		{
+			name:   "not included ecosystem",
+			filter: pkg.Filter{IncludeEcosystems: []string{"npm_and_yarn"}},
+			reason: `ecosystem "go_modules" is not included`,
+		},
+		{
+			name:   "excluded ecosystem",
+			filter: pkg.Filter{ExcludeEcosystems: []string{"go_modules"}},
+			reason: `ecosystem "go_modules" is excluded`,
+		},
+		{
+			name:   "included directory glob",
+			filter: pkg.Filter{IncludeDirectories: []string{"/too*"}},
+			match:  true,
+		},
+		{
+			name:   "included directory with trailing slash",
+			filter: pkg.Filter{IncludeDirectories: []string{"/tools/"}},
+			match:  true,
+		},
+		{
+			name:   "excluded directory with trailing slash",
+			filter: pkg.Filter{ExcludeDirectories: []string{"/tools/"}},
+			reason: `directory "/tools" is excluded`,
+		},
+		{
+			name:   "included directory regexp",
+			filter: pkg.Filter{IncludeDirectories: []string{"re:^/to"}},
+			match:  true,
+		},
+		{
+			name:   "not included directory",
+			filter: pkg.Filter{IncludeDirectories: []string{"/"}},
+			reason: `directory "/tools" is not included`,
+		},
+		{
+			name:   "excluded directory",
+			filter: pkg.Filter{ExcludeDirectories: []string{"/tool?"}},
+			reason: `directory "/tools" is excluded`,
+		},
+		{
+			name:   "excluded dependency glob",
+			filter: pkg.Filter{ExcludeDependencies: []string{"k8s.io/*"}},
+			reason: `dependency "k8s.io/api" is excluded`,
+		},
+		{
+			name:   "included dependency regexp",
+			filter: pkg.Filter{IncludeDependencies: []string{`re:^k8s\.io/`}},
+			match:  true,
+		},
+		{
+			name:   "not included dependency regexp",
+			filter: pkg.Filter{IncludeDependencies: []string{`re:^github\.com/`}},
+			reason: `dependency "k8s.io/api" is not included`,
+		},
+		{
+			name:   "included label",
+			filter: pkg.Filter{IncludeLabels: []string{"dependencies"}},
+			labels: []string{"go", "dependencies"},
+			match:  true,
+		},
+		{
+			name:   "missing label",
+			filter: pkg.Filter{IncludeLabels: []string{"dependencies"}},
+			labels: []string{"go"},
+			reason: "none of the labels [dependencies] is present",
+		},
+		{
+			name:   "excluded label",
+			filter: pkg.Filter{ExcludeLabels: []string{"do-not-bundle"}},
+			labels: []string{"do-not-bundle"},
+			reason: `label "do-not-bundle" is excluded`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			match, reason := tc.filter.Match(update, tc.labels)
+			assert.Equal(t, tc.match, match)
+			assert.Equal(t, tc.reason, reason)
+		})
+	}
+}
+
+func TestFilterDirectoryIsAnchored(t *testing.T) {
+	filter := pkg.Filter{IncludeDirectories: []string{"/tools/"}}
+
+	match, reason := filter.Match(metadata.Update{Directory: "/x/tools/y"}, nil)
+	assert.False(t, match)
+	assert.Equal(t, `directory "/x/tools/y" is not included`, reason)
+}
+
+func TestFilterValidate(t *testing.T) {
+	assert.NoError(t, pkg.Filter{ExcludeDependencies: []string{"k8s.io/*", "re:^golang\\.org/"}}.Validate())
+	assert.ErrorContains(t, pkg.Filter{IncludeDependencies: []string{"re:("}}.Validate(), "invalid regular expression")
+}
+
+func TestBundlerSkipsFilteredPRs(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.Filter = pkg.Filter{
+		ExcludeDependencies: []string{"k8s.io/*"},
+		IncludeLabels:       []string{"dependencies"},
+	}
+	f.issues.ListByRepoReturns([]*github.Issue{
+		{
+			Number:           github.Int(1),
+			Body:             github.String("Bumps [k8s.io/api](https://github.com/kubernetes/api)"),
+			Labels:           []*github.Label{{Name: github.String("dependencies")}},
+			PullRequestLinks: &github.PullRequestLinks{},
+		},
+		{
+			Number:           github.Int(2),
+			Body:             github.String("Bumps [github.com/test/test](github.com/test/test)"),
+			PullRequestLinks: &github.PullRequestLinks{},
+		},
+		{
+			Number:           github.Int(3),
+			Body:             github.String("Bumps [github.com/test/other](github.com/test/other)"),
+			Labels:           []*github.Label{{Name: github.String("dependencies")}},
+			PullRequestLinks: &github.PullRequestLinks{},
+		},
+	}, &github.Response{}, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	require.Equal(t, 1, f.updater.UpdateCallCount())
+	body, _, _ := f.updater.UpdateArgsForCall(0)
+	assert.Equal(t, "Bumps [github.com/test/other](github.com/test/other)", body)
+	_, _, _, newPR := f.pulls.CreateArgsForCall(0)
+	assert.Contains(t, newPR.GetBody(), "<!-- dependabot-bundler-prs: 3 -->")
+}
+
+func TestBundlerInvalidFilter(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.Filter = pkg.Filter{ExcludeDependencies: []string{"re:["}}
+
+	assert.ErrorContains(t, bundler.Bundle(), "invalid filter")
+	assert.Equal(t, 0, f.issues.ListByRepoCallCount())
+}
+
+func TestBundlerExcludesMajorUpdates(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.ExcludeMajor = true
+	f.issues.ListByRepoReturns([]*github.Issue{
+		{Number: github.Int(1), PullRequestLinks: &github.PullRequestLinks{}},
+		{Number: github.Int(2), PullRequestLinks: &github.PullRequestLinks{}},
+	}, &github.Response{}, nil)
+	f.pulls.GetReturnsOnCall(0, &github.PullRequest{
+		Title: github.String("Bump github.com/test/test from 1.0.0 to 2.0.0"),
+	}, nil, nil)
+	f.pulls.GetReturnsOnCall(1, &github.PullRequest{
+		Title: github.String("Bump github.com/test/other from 1.0.0 to 1.1.0"),
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	require.Equal(t, 1, f.updater.UpdateCallCount())
+	_, _, title := f.updater.UpdateArgsForCall(0)
+	assert.Equal(t, "Bump github.com/test/other from 1.0.0 to 1.1.0", title)
+	_, _, _, newPR := f.pulls.CreateArgsForCall(0)
+	assert.Contains(t, newPR.GetBody(), "### Excluded")
+	assert.Contains(t, newPR.GetBody(), "| `github.com/test/test` | - | `/` | `1.0.0` → `2.0.0` | major | #1 |")
+	assert.Contains(t, newPR.GetBody(), "<!-- dependabot-bundler-prs: 2 -->")
+}
+
+func TestBundlerSkipsUndeclaredPRs(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.Declared = []pkg.Declaration{
+		{Ecosystem: "go_modules", Directory: "/"},
+		{Ecosystem: "github_actions", Directory: "/.github/actions/*"},
+	}
+	f.issues.ListByRepoReturns([]*github.Issue{
+		{Number: github.Int(1), PullRequestLinks: &github.PullRequestLinks{}},
+		{Number: github.Int(2), PullRequestLinks: &github.PullRequestLinks{}},
+		{Number: github.Int(3), PullRequestLinks: &github.PullRequestLinks{}},
+	}, &github.Response{}, nil)
+	f.pulls.GetReturnsOnCall(0, &github.PullRequest{
+		Title: github.String("Bump github.com/test/test from 1.0.0 to 1.1.0 in /tools"),
+		Head:  &github.PullRequestBranch{Ref: github.String("dependabot/go_modules/tools/github.com/test/test-1.1.0")},
+	}, nil, nil)
+	f.pulls.GetReturnsOnCall(1, &github.PullRequest{
+		Title: github.String("Bump actions/checkout from 3 to 4 in /.github/actions/build"),
+		Head:  &github.PullRequestBranch{Ref: github.String("dependabot/github_actions/.github/actions/build/checkout-4")},
+	}, nil, nil)
+	f.pulls.GetReturnsOnCall(2, &github.PullRequest{
+		Title: github.String("Bump lodash from 1.0.0 to 1.1.0"),
+		Head:  &github.PullRequestBranch{Ref: github.String("dependabot/npm_and_yarn/lodash-1.1.0")},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	require.Equal(t, 1, f.updater.UpdateCallCount())
+	_, _, _, newPR := f.pulls.CreateArgsForCall(0)
+	assert.Contains(t, newPR.GetBody(), "<!-- dependabot-bundler-prs: 2 -->")
+}
+
+func TestBundlerReadsUpdateTypeFromCommit(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.ExcludeMajor = true
+	// the actions are pinned to commits, so the level can't be computed from the versions
+	f.pulls.GetReturns(&github.PullRequest{
+		Title: github.String("Bump actions/checkout from 8ade135a41bc03ea155e62e844d188df1ea18608 to " +
+			"b4ffde65f46336ab88eb53be808477a3936bae11"),
+		Body: github.String(`Bumps [actions/checkout](https://github.com/actions/checkout) from 8ade135a41bc03ea155e62e844d188df1ea18608 to b4ffde65f46336ab88eb53be808477a3936bae11.
+<details>
+<summary>Release notes</summary>
+<p><em>Sourced from <a href="https://github.com/actions/checkout/releases">actions/checkout's releases</a>.</em></p>
+</details>
+<br />
+
+
+[![Dependabot compatibility score](https://dependabot-badges.githubapp.com/badges/compatibility_score?dependency-name=actions/checkout&package-manager=github_actions&previous-version=8ade135a41bc03ea155e62e844d188df1ea18608&new-version=b4ffde65f46336ab88eb53be808477a3936bae11)](https://docs.github.com/en/github/managing-security-vulnerabilities/about-dependabot-security-updates#about-compatibility-scores)
+
+Dependabot will resolve any conflicts with this PR as long as you don't alter it yourself.`),
+		Head: &github.PullRequestBranch{Ref: github.String("dependabot/github_actions/actions/checkout-b4ffde65f4")},
+	}, nil, nil)
+	f.pulls.ListCommitsReturns([]*github.RepositoryCommit{
+		{
+			SHA: github.String("aaa"),
+			Commit: &github.Commit{Message: github.String(`Bump actions/checkout from 8ade135a41bc03ea155e62e844d188df1ea18608 to b4ffde65f46336ab88eb53be808477a3936bae11
+
+Bumps [actions/checkout](https://github.com/actions/checkout) from 8ade135a41bc03ea155e62e844d188df1ea18608 to b4ffde65f46336ab88eb53be808477a3936bae11.
+- [Release notes](https://github.com/actions/checkout/releases)
+- [Commits](https://github.com/actions/checkout/compare/8ade135a41bc03ea155e62e844d188df1ea18608...b4ffde65f46336ab88eb53be808477a3936bae11)
+
+---
+updated-dependencies:
+- dependency-name: actions/checkout
+  dependency-type: direct:production
+  update-type: version-update:semver-major
+...
+
+Signed-off-by: dependabot[bot] <support@github.com>`)},
+		},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	require.Equal(t, 1, f.pulls.ListCommitsCallCount())
+	_, _, _, number, _ := f.pulls.ListCommitsArgsForCall(0)
+	assert.Equal(t, 1, number)
+	assert.Equal(t, 0, f.updater.UpdateCallCount())
+	assert.Equal(t, 0, f.pulls.CreateCallCount())
+}
@@ -0,0 +1,583 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/forge"
+)
+
+type FakeForge struct {
+	AddLabelsStub        func(context.Context, int, []string) error
+	addLabelsMutex       sync.RWMutex
+	addLabelsArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 []string
+	}
+	addLabelsReturns struct {
+		result1 error
+	}
+	addLabelsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CommitFilesStub        func(context.Context, forge.Commit) error
+	commitFilesMutex       sync.RWMutex
+	commitFilesArgsForCall []struct {
+		arg1 context.Context
+		arg2 forge.Commit
+	}
+	commitFilesReturns struct {
+		result1 error
+	}
+	commitFilesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateBranchStub        func(context.Context, string, string) error
+	createBranchMutex       sync.RWMutex
+	createBranchArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	createBranchReturns struct {
+		result1 error
+	}
+	createBranchReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetChangeRequestStub        func(context.Context, int) (*forge.ChangeRequest, error)
+	getChangeRequestMutex       sync.RWMutex
+	getChangeRequestArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	getChangeRequestReturns struct {
+		result1 *forge.ChangeRequest
+		result2 error
+	}
+	getChangeRequestReturnsOnCall map[int]struct {
+		result1 *forge.ChangeRequest
+		result2 error
+	}
+	ListChangeRequestsStub        func(context.Context, string) ([]*forge.ChangeRequest, error)
+	listChangeRequestsMutex       sync.RWMutex
+	listChangeRequestsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	listChangeRequestsReturns struct {
+		result1 []*forge.ChangeRequest
+		result2 error
+	}
+	listChangeRequestsReturnsOnCall map[int]struct {
+		result1 []*forge.ChangeRequest
+		result2 error
+	}
+	OpenChangeRequestStub        func(context.Context, forge.NewChangeRequest) (*forge.ChangeRequest, error)
+	openChangeRequestMutex       sync.RWMutex
+	openChangeRequestArgsForCall []struct {
+		arg1 context.Context
+		arg2 forge.NewChangeRequest
+	}
+	openChangeRequestReturns struct {
+		result1 *forge.ChangeRequest
+		result2 error
+	}
+	openChangeRequestReturnsOnCall map[int]struct {
+		result1 *forge.ChangeRequest
+		result2 error
+	}
+	ReferencePrefixStub        func() string
+	referencePrefixMutex       sync.RWMutex
+	referencePrefixArgsForCall []struct {
+	}
+	referencePrefixReturns struct {
+		result1 string
+	}
+	referencePrefixReturnsOnCall map[int]struct {
+		result1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeForge) AddLabels(arg1 context.Context, arg2 int, arg3 []string) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.addLabelsMutex.Lock()
+	ret, specificReturn := fake.addLabelsReturnsOnCall[len(fake.addLabelsArgsForCall)]
+	fake.addLabelsArgsForCall = append(fake.addLabelsArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.AddLabelsStub
+	fakeReturns := fake.addLabelsReturns
+	fake.recordInvocation("AddLabels", []interface{}{arg1, arg2, arg3Copy})
+	fake.addLabelsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeForge) AddLabelsCallCount() int {
+	fake.addLabelsMutex.RLock()
+	defer fake.addLabelsMutex.RUnlock()
+	return len(fake.addLabelsArgsForCall)
+}
+
+func (fake *FakeForge) AddLabelsCalls(stub func(context.Context, int, []string) error) {
+	fake.addLabelsMutex.Lock()
+	defer fake.addLabelsMutex.Unlock()
+	fake.AddLabelsStub = stub
+}
+
+func (fake *FakeForge) AddLabelsArgsForCall(i int) (context.Context, int, []string) {
+	fake.addLabelsMutex.RLock()
+	defer fake.addLabelsMutex.RUnlock()
+	argsForCall := fake.addLabelsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeForge) AddLabelsReturns(result1 error) {
+	fake.addLabelsMutex.Lock()
+	defer fake.addLabelsMutex.Unlock()
+	fake.AddLabelsStub = nil
+	fake.addLabelsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeForge) AddLabelsReturnsOnCall(i int, result1 error) {
+	fake.addLabelsMutex.Lock()
+	defer fake.addLabelsMutex.Unlock()
+	fake.AddLabelsStub = nil
+	if fake.addLabelsReturnsOnCall == nil {
+		fake.addLabelsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addLabelsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeForge) CommitFiles(arg1 context.Context, arg2 forge.Commit) error {
+	fake.commitFilesMutex.Lock()
+	ret, specificReturn := fake.commitFilesReturnsOnCall[len(fake.commitFilesArgsForCall)]
+	fake.commitFilesArgsForCall = append(fake.commitFilesArgsForCall, struct {
+		arg1 context.Context
+		arg2 forge.Commit
+	}{arg1, arg2})
+	stub := fake.CommitFilesStub
+	fakeReturns := fake.commitFilesReturns
+	fake.recordInvocation("CommitFiles", []interface{}{arg1, arg2})
+	fake.commitFilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeForge) CommitFilesCallCount() int {
+	fake.commitFilesMutex.RLock()
+	defer fake.commitFilesMutex.RUnlock()
+	return len(fake.commitFilesArgsForCall)
+}
+
+func (fake *FakeForge) CommitFilesCalls(stub func(context.Context, forge.Commit) error) {
+	fake.commitFilesMutex.Lock()
+	defer fake.commitFilesMutex.Unlock()
+	fake.CommitFilesStub = stub
+}
+
+func (fake *FakeForge) CommitFilesArgsForCall(i int) (context.Context, forge.Commit) {
+	fake.commitFilesMutex.RLock()
+	defer fake.commitFilesMutex.RUnlock()
+	argsForCall := fake.commitFilesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeForge) CommitFilesReturns(result1 error) {
+	fake.commitFilesMutex.Lock()
+	defer fake.commitFilesMutex.Unlock()
+	fake.CommitFilesStub = nil
+	fake.commitFilesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeForge) CommitFilesReturnsOnCall(i int, result1 error) {
+	fake.commitFilesMutex.Lock()
+	defer fake.commitFilesMutex.Unlock()
+	fake.CommitFilesStub = nil
+	if fake.commitFilesReturnsOnCall == nil {
+		fake.commitFilesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.commitFilesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeForge) CreateBranch(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.createBranchMutex.Lock()
+	ret, specificReturn := fake.createBranchReturnsOnCall[len(fake.createBranchArgsForCall)]
+	fake.createBranchArgsForCall = append(fake.createBranchArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CreateBranchStub
+	fakeReturns := fake.createBranchReturns
+	fake.recordInvocation("CreateBranch", []interface{}{arg1, arg2, arg3})
+	fake.createBranchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeForge) CreateBranchCallCount() int {
+	fake.createBranchMutex.RLock()
+	defer fake.createBranchMutex.RUnlock()
+	return len(fake.createBranchArgsForCall)
+}
+
+func (fake *FakeForge) CreateBranchCalls(stub func(context.Context, string, string) error) {
+	fake.createBranchMutex.Lock()
+	defer fake.createBranchMutex.Unlock()
+	fake.CreateBranchStub = stub
+}
+
+func (fake *FakeForge) CreateBranchArgsForCall(i int) (context.Context, string, string) {
+	fake.createBranchMutex.RLock()
+	defer fake.createBranchMutex.RUnlock()
+	argsForCall := fake.createBranchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeForge) CreateBranchReturns(result1 error) {
+	fake.createBranchMutex.Lock()
+	defer fake.createBranchMutex.Unlock()
+	fake.CreateBranchStub = nil
+	fake.createBranchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeForge) CreateBranchReturnsOnCall(i int, result1 error) {
+	fake.createBranchMutex.Lock()
+	defer fake.createBranchMutex.Unlock()
+	fake.CreateBranchStub = nil
+	if fake.createBranchReturnsOnCall == nil {
+		fake.createBranchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createBranchReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeForge) GetChangeRequest(arg1 context.Context, arg2 int) (*forge.ChangeRequest, error) {
+	fake.getChangeRequestMutex.Lock()
+	ret, specificReturn := fake.getChangeRequestReturnsOnCall[len(fake.getChangeRequestArgsForCall)]
+	fake.getChangeRequestArgsForCall = append(fake.getChangeRequestArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.GetChangeRequestStub
+	fakeReturns := fake.getChangeRequestReturns
+	fake.recordInvocation("GetChangeRequest", []interface{}{arg1, arg2})
+	fake.getChangeRequestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeForge) GetChangeRequestCallCount() int {
+	fake.getChangeRequestMutex.RLock()
+	defer fake.getChangeRequestMutex.RUnlock()
+	return len(fake.getChangeRequestArgsForCall)
+}
+
+func (fake *FakeForge) GetChangeRequestCalls(stub func(context.Context, int) (*forge.ChangeRequest, error)) {
+	fake.getChangeRequestMutex.Lock()
+	defer fake.getChangeRequestMutex.Unlock()
+	fake.GetChangeRequestStub = stub
+}
+
+func (fake *FakeForge) GetChangeRequestArgsForCall(i int) (context.Context, int) {
+	fake.getChangeRequestMutex.RLock()
+	defer fake.getChangeRequestMutex.RUnlock()
+	argsForCall := fake.getChangeRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeForge) GetChangeRequestReturns(result1 *forge.ChangeRequest, result2 error) {
+	fake.getChangeRequestMutex.Lock()
+	defer fake.getChangeRequestMutex.Unlock()
+	fake.GetChangeRequestStub = nil
+	fake.getChangeRequestReturns = struct {
+		result1 *forge.ChangeRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeForge) GetChangeRequestReturnsOnCall(i int, result1 *forge.ChangeRequest, result2 error) {
+	fake.getChangeRequestMutex.Lock()
+	defer fake.getChangeRequestMutex.Unlock()
+	fake.GetChangeRequestStub = nil
+	if fake.getChangeRequestReturnsOnCall == nil {
+		fake.getChangeRequestReturnsOnCall = make(map[int]struct {
+			result1 *forge.ChangeRequest
+			result2 error
+		})
+	}
+	fake.getChangeRequestReturnsOnCall[i] = struct {
+		result1 *forge.ChangeRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeForge) ListChangeRequests(arg1 context.Context, arg2 string) ([]*forge.ChangeRequest, error) {
+	fake.listChangeRequestsMutex.Lock()
+	ret, specificReturn := fake.listChangeRequestsReturnsOnCall[len(fake.listChangeRequestsArgsForCall)]
+	fake.listChangeRequestsArgsForCall = append(fake.listChangeRequestsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ListChangeRequestsStub
+	fakeReturns := fake.listChangeRequestsReturns
+	fake.recordInvocation("ListChangeRequests", []interface{}{arg1, arg2})
+	fake.listChangeRequestsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeForge) ListChangeRequestsCallCount() int {
+	fake.listChangeRequestsMutex.RLock()
+	defer fake.listChangeRequestsMutex.RUnlock()
+	return len(fake.listChangeRequestsArgsForCall)
+}
+
+func (fake *FakeForge) ListChangeRequestsCalls(stub func(context.Context, string) ([]*forge.ChangeRequest, error)) {
+	fake.listChangeRequestsMutex.Lock()
+	defer fake.listChangeRequestsMutex.Unlock()
+	fake.ListChangeRequestsStub = stub
+}
+
+func (fake *FakeForge) ListChangeRequestsArgsForCall(i int) (context.Context, string) {
+	fake.listChangeRequestsMutex.RLock()
+	defer fake.listChangeRequestsMutex.RUnlock()
+	argsForCall := fake.listChangeRequestsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeForge) ListChangeRequestsReturns(result1 []*forge.ChangeRequest, result2 error) {
+	fake.listChangeRequestsMutex.Lock()
+	defer fake.listChangeRequestsMutex.Unlock()
+	fake.ListChangeRequestsStub = nil
+	fake.listChangeRequestsReturns = struct {
+		result1 []*forge.ChangeRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeForge) ListChangeRequestsReturnsOnCall(i int, result1 []*forge.ChangeRequest, result2 error) {
+	fake.listChangeRequestsMutex.Lock()
+	defer fake.listChangeRequestsMutex.Unlock()
+	fake.ListChangeRequestsStub = nil
+	if fake.listChangeRequestsReturnsOnCall == nil {
+		fake.listChangeRequestsReturnsOnCall = make(map[int]struct {
+			result1 []*forge.ChangeRequest
+			result2 error
+		})
+	}
+	fake.listChangeRequestsReturnsOnCall[i] = struct {
+		result1 []*forge.ChangeRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeForge) OpenChangeRequest(arg1 context.Context, arg2 forge.NewChangeRequest) (*forge.ChangeRequest, error) {
+	fake.openChangeRequestMutex.Lock()
+	ret, specificReturn := fake.openChangeRequestReturnsOnCall[len(fake.openChangeRequestArgsForCall)]
+	fake.openChangeRequestArgsForCall = append(fake.openChangeRequestArgsForCall, struct {
+		arg1 context.Context
+		arg2 forge.NewChangeRequest
+	}{arg1, arg2})
+	stub := fake.OpenChangeRequestStub
+	fakeReturns := fake.openChangeRequestReturns
+	fake.recordInvocation("OpenChangeRequest", []interface{}{arg1, arg2})
+	fake.openChangeRequestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeForge) OpenChangeRequestCallCount() int {
+	fake.openChangeRequestMutex.RLock()
+	defer fake.openChangeRequestMutex.RUnlock()
+	return len(fake.openChangeRequestArgsForCall)
+}
+
+func (fake *FakeForge) OpenChangeRequestCalls(stub func(context.Context, forge.NewChangeRequest) (*forge.ChangeRequest, error)) {
+	fake.openChangeRequestMutex.Lock()
+	defer fake.openChangeRequestMutex.Unlock()
+	fake.OpenChangeRequestStub = stub
+}
+
+func (fake *FakeForge) OpenChangeRequestArgsForCall(i int) (context.Context, forge.NewChangeRequest) {
+	fake.openChangeRequestMutex.RLock()
+	defer fake.openChangeRequestMutex.RUnlock()
+	argsForCall := fake.openChangeRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeForge) OpenChangeRequestReturns(result1 *forge.ChangeRequest, result2 error) {
+	fake.openChangeRequestMutex.Lock()
+	defer fake.openChangeRequestMutex.Unlock()
+	fake.OpenChangeRequestStub = nil
+	fake.openChangeRequestReturns = struct {
+		result1 *forge.ChangeRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeForge) OpenChangeRequestReturnsOnCall(i int, result1 *forge.ChangeRequest, result2 error) {
+	fake.openChangeRequestMutex.Lock()
+	defer fake.openChangeRequestMutex.Unlock()
+	fake.OpenChangeRequestStub = nil
+	if fake.openChangeRequestReturnsOnCall == nil {
+		fake.openChangeRequestReturnsOnCall = make(map[int]struct {
+			result1 *forge.ChangeRequest
+			result2 error
+		})
+	}
+	fake.openChangeRequestReturnsOnCall[i] = struct {
+		result1 *forge.ChangeRequest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeForge) ReferencePrefix() string {
+	fake.referencePrefixMutex.Lock()
+	ret, specificReturn := fake.referencePrefixReturnsOnCall[len(fake.referencePrefixArgsForCall)]
+	fake.referencePrefixArgsForCall = append(fake.referencePrefixArgsForCall, struct {
+	}{})
+	stub := fake.ReferencePrefixStub
+	fakeReturns := fake.referencePrefixReturns
+	fake.recordInvocation("ReferencePrefix", []interface{}{})
+	fake.referencePrefixMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeForge) ReferencePrefixCallCount() int {
+	fake.referencePrefixMutex.RLock()
+	defer fake.referencePrefixMutex.RUnlock()
+	return len(fake.referencePrefixArgsForCall)
+}
+
+func (fake *FakeForge) ReferencePrefixCalls(stub func() string) {
+	fake.referencePrefixMutex.Lock()
+	defer fake.referencePrefixMutex.Unlock()
+	fake.ReferencePrefixStub = stub
+}
+
+func (fake *FakeForge) ReferencePrefixReturns(result1 string) {
+	fake.referencePrefixMutex.Lock()
+	defer fake.referencePrefixMutex.Unlock()
+	fake.ReferencePrefixStub = nil
+	fake.referencePrefixReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeForge) ReferencePrefixReturnsOnCall(i int, result1 string) {
+	fake.referencePrefixMutex.Lock()
+	defer fake.referencePrefixMutex.Unlock()
+	fake.ReferencePrefixStub = nil
+	if fake.referencePrefixReturnsOnCall == nil {
+		fake.referencePrefixReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.referencePrefixReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeForge) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.addLabelsMutex.RLock()
+	defer fake.addLabelsMutex.RUnlock()
+	fake.commitFilesMutex.RLock()
+	defer fake.commitFilesMutex.RUnlock()
+	fake.createBranchMutex.RLock()
+	defer fake.createBranchMutex.RUnlock()
+	fake.getChangeRequestMutex.RLock()
+	defer fake.getChangeRequestMutex.RUnlock()
+	fake.listChangeRequestsMutex.RLock()
+	defer fake.listChangeRequestsMutex.RUnlock()
+	fake.openChangeRequestMutex.RLock()
+	defer fake.openChangeRequestMutex.RUnlock()
+	fake.referencePrefixMutex.RLock()
+	defer fake.referencePrefixMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeForge) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ forge.Forge = new(FakeForge)
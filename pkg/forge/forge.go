@@ -0,0 +1,102 @@
+// Package forge abstracts the code hosting platform the bundler works with, so PRs of GitHub and MRs of
+// GitLab can be bundled the same way.
+package forge
+
+import (
+	"context"
+	"errors"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Forge provides the operations the bundler needs from a code hosting platform. Pull requests and merge
+// requests are both called change requests.
+//
+//counterfeiter:generate -o fakes/fake_forge.go . Forge
+type Forge interface {
+	// ListChangeRequests lists the open change requests which were opened by the author.
+	ListChangeRequests(ctx context.Context, author string) ([]*ChangeRequest, error)
+	// GetChangeRequest returns the change request with the number, in any state.
+	GetChangeRequest(ctx context.Context, number int) (*ChangeRequest, error)
+	// CreateBranch creates the branch from the head of base.
+	CreateBranch(ctx context.Context, branch, base string) error
+	// CommitFiles creates a commit with the files on top of the branch.
+	CommitFiles(ctx context.Context, commit Commit) error
+	// OpenChangeRequest opens a change request and returns it.
+	OpenChangeRequest(ctx context.Context, request NewChangeRequest) (*ChangeRequest, error)
+	// AddLabels adds the labels to the change request.
+	AddLabels(ctx context.Context, number int, labels []string) error
+	// ReferencePrefix returns the prefix of the number of a change request which links to it in descriptions
+	// and commit messages.
+	ReferencePrefix() string
+}
+
+// States of a change request.
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+	StateMerged = "merged"
+)
+
+// ChangeRequest is a pull request on GitHub or a merge request on GitLab.
+type ChangeRequest struct {
+	// Number is the number of the PR or the IID of the MR.
+	Number       int
+	URL          string
+	Title        string
+	Body         string
+	SourceBranch string
+	TargetBranch string
+	// State is one of StateOpen, StateClosed or StateMerged.
+	State  string
+	Labels []string
+	// Author is the account which opened the change request.
+	Author Author
+	// HeadRepository and BaseRepository identify the repositories of the source and the target branch.
+	// They differ for change requests from forks.
+	HeadRepository string
+	BaseRepository string
+}
+
+// Author is the account which opened a change request.
+type Author struct {
+	Login string
+	ID    int64
+	// Bot is set for bot accounts. Only GitHub reports it.
+	Bot bool
+}
+
+// NewChangeRequest describes a change request to open.
+type NewChangeRequest struct {
+	Title        string
+	Body         string
+	SourceBranch string
+	TargetBranch string
+}
+
+// Commit describes a commit to create.
+type Commit struct {
+	Branch      string
+	Message     string
+	AuthorName  string
+	AuthorEmail string
+	Files       []File
+}
+
+// File is a file of a commit.
+type File struct {
+	Path    string
+	Content []byte
+	// Executable sets the executable bit of the file.
+	Executable bool
+	// Added is set for files which don't exist on the branch yet.
+	Added bool
+	// Deleted removes the file from the branch. Content is ignored.
+	Deleted bool
+	// Symlink marks a symbolic link. Content is the target of the link.
+	Symlink bool
+}
+
+// errSymlink is returned by the forges whose API can't create symbolic links. Committing the target as the
+// content of a regular file would silently replace the link.
+var errSymlink = errors.New("symbolic links can't be committed through the API of the forge, use the git backend")
@@ -0,0 +1,256 @@
+package forge
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Gitea implements Forge with the API of Gitea and Forgejo. Commits are created with the contents API,
+// which needs Gitea 1.20 or Forgejo 1.20 and doesn't support the executable bit.
+type Gitea struct {
+	// BaseURL is the URL of the instance, for example https://codeberg.org.
+	BaseURL string
+	Owner   string
+	Repo    string
+	// Token is an access token with read and write access to the repository and its issues.
+	Token string
+	// Client is the HTTP client used for the requests. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+var _ Forge = &Gitea{}
+
+// giteaPageSize is the default maximum number of items per page of Gitea.
+const giteaPageSize = 50
+
+// giteaPullRequest is a pull request as returned by the API.
+type giteaPullRequest struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	Merged  bool   `json:"merged"`
+	User    struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+	} `json:"user"`
+	Head   giteaBranch `json:"head"`
+	Base   giteaBranch `json:"base"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// giteaBranch is the source or target branch of a pull request.
+type giteaBranch struct {
+	Ref  string `json:"ref"`
+	Repo struct {
+		FullName string `json:"full_name"`
+	} `json:"repo"`
+}
+
+// giteaFileOperation is a file operation of the contents API.
+type giteaFileOperation struct {
+	Operation string `json:"operation"`
+	Path      string `json:"path"`
+	Content   string `json:"content,omitempty"`
+	SHA       string `json:"sha,omitempty"`
+}
+
+// ListChangeRequests lists the open pull requests of the author. The API can't filter pull requests by author,
+// so every open pull request is listed.
+func (g *Gitea) ListChangeRequests(ctx context.Context, author string) ([]*ChangeRequest, error) {
+	var result []*ChangeRequest
+
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("state", "open")
+		query.Set("limit", strconv.Itoa(giteaPageSize))
+		query.Set("page", strconv.Itoa(page))
+
+		var prs []giteaPullRequest
+		if err := g.do(ctx, http.MethodGet, "pulls?"+query.Encode(), nil, &prs); err != nil {
+			return nil, fmt.Errorf("failed to list pull requests: %w", err)
+		}
+
+		for _, pr := range prs {
+			if strings.EqualFold(pr.User.Login, author) {
+				result = append(result, pr.changeRequest())
+			}
+		}
+
+		// like on the other forges, only the first hundred are bundled
+		if len(prs) < giteaPageSize || len(result) >= defaultNumberOfItemsPerPage {
+			return result, nil
+		}
+	}
+}
+
+// GetChangeRequest returns the pull request with the number.
+func (g *Gitea) GetChangeRequest(ctx context.Context, number int) (*ChangeRequest, error) {
+	var pr giteaPullRequest
+	if err := g.do(ctx, http.MethodGet, fmt.Sprintf("pulls/%d", number), nil, &pr); err != nil {
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	return pr.changeRequest(), nil
+}
+
+// CreateBranch creates the branch from the head of base.
+func (g *Gitea) CreateBranch(ctx context.Context, branch, base string) error {
+	body := map[string]string{"new_branch_name": branch, "old_branch_name": base}
+	if err := g.do(ctx, http.MethodPost, "branches", body, nil); err != nil {
+		return fmt.Errorf("failed to create branch: %w", err)
+	}
+
+	return nil
+}
+
+// CommitFiles creates a commit with the files on top of the branch. Changing and deleting a file needs its
+// current SHA, which is looked up first.
+func (g *Gitea) CommitFiles(ctx context.Context, commit Commit) error {
+	files := make([]giteaFileOperation, 0, len(commit.Files))
+
+	for _, file := range commit.Files {
+		if file.Symlink && !file.Deleted {
+			return fmt.Errorf("failed to commit %s: %w", file.Path, errSymlink)
+		}
+
+		operation := giteaFileOperation{Operation: "create", Path: file.Path}
+
+		if !file.Added {
+			sha, err := g.fileSHA(ctx, file.Path, commit.Branch)
+			if err != nil {
+				return err
+			}
+
+			operation.Operation = "update"
+			operation.SHA = sha
+		}
+
+		if file.Deleted {
+			operation.Operation = "delete"
+		} else {
+			operation.Content = base64.StdEncoding.EncodeToString(file.Content)
+		}
+
+		files = append(files, operation)
+	}
+
+	identity := map[string]string{"name": commit.AuthorName, "email": commit.AuthorEmail}
+	body := map[string]any{
+		"branch":    commit.Branch,
+		"message":   commit.Message,
+		"author":    identity,
+		"committer": identity,
+		"files":     files,
+	}
+
+	if err := g.do(ctx, http.MethodPost, "contents", body, nil); err != nil {
+		return fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	return nil
+}
+
+// fileSHA returns the blob SHA of the file on the branch.
+func (g *Gitea) fileSHA(ctx context.Context, path, branch string) (string, error) {
+	var content struct {
+		SHA string `json:"sha"`
+	}
+
+	segments := strings.Split(path, "/")
+	escaped := make([]string, 0, len(segments))
+
+	for _, segment := range segments {
+		escaped = append(escaped, url.PathEscape(segment))
+	}
+
+	endpoint := "contents/" + strings.Join(escaped, "/") + "?ref=" + url.QueryEscape(branch)
+	if err := g.do(ctx, http.MethodGet, endpoint, nil, &content); err != nil {
+		return "", fmt.Errorf("failed to get content of %s: %w", path, err)
+	}
+
+	return content.SHA, nil
+}
+
+// OpenChangeRequest opens a pull request.
+func (g *Gitea) OpenChangeRequest(ctx context.Context, request NewChangeRequest) (*ChangeRequest, error) {
+	body := map[string]string{
+		"head":  request.SourceBranch,
+		"base":  request.TargetBranch,
+		"title": request.Title,
+		"body":  request.Body,
+	}
+
+	var pr giteaPullRequest
+	if err := g.do(ctx, http.MethodPost, "pulls", body, &pr); err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	return pr.changeRequest(), nil
+}
+
+// AddLabels adds the labels to the pull request. The labels are passed by name, which needs Gitea 1.19.
+func (g *Gitea) AddLabels(ctx context.Context, number int, labels []string) error {
+	body := map[string][]string{"labels": labels}
+	if err := g.do(ctx, http.MethodPost, fmt.Sprintf("issues/%d/labels", number), body, nil); err != nil {
+		return fmt.Errorf("failed to add labels to pull request: %w", err)
+	}
+
+	return nil
+}
+
+// ReferencePrefix returns the prefix of references to pull requests, which share their numbers with the issues.
+func (g *Gitea) ReferencePrefix() string {
+	return "#"
+}
+
+// do sends a request to the path below the repository.
+func (g *Gitea) do(ctx context.Context, method, path string, body, result any) error {
+	endpoint := fmt.Sprintf("%s/api/v1/repos/%s/%s/%s",
+		strings.TrimSuffix(g.BaseURL, "/"), url.PathEscape(g.Owner), url.PathEscape(g.Repo), path)
+	header := http.Header{}
+	header.Set("Authorization", "token "+g.Token)
+
+	return doJSON(ctx, g.Client, method, endpoint, header, body, result)
+}
+
+func (pr giteaPullRequest) changeRequest() *ChangeRequest {
+	state := StateOpen
+
+	switch {
+	case pr.Merged:
+		state = StateMerged
+	case pr.State == "closed":
+		state = StateClosed
+	}
+
+	labels := make([]string, 0, len(pr.Labels))
+	for _, label := range pr.Labels {
+		labels = append(labels, label.Name)
+	}
+
+	return &ChangeRequest{
+		Number:       pr.Number,
+		URL:          pr.HTMLURL,
+		Title:        pr.Title,
+		Body:         pr.Body,
+		SourceBranch: pr.Head.Ref,
+		TargetBranch: pr.Base.Ref,
+		State:        state,
+		Labels:       labels,
+		Author: Author{
+			Login: pr.User.Login,
+			ID:    pr.User.ID,
+		},
+		HeadRepository: pr.Head.Repo.FullName,
+		BaseRepository: pr.Base.Repo.FullName,
+	}
+}
@@ -0,0 +1,152 @@
+package forge
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// interaction is a recorded request together with the response of the server.
+type interaction struct {
+	Request struct {
+		Method string `yaml:"method"`
+		URI    string `yaml:"uri"`
+		Body   string `yaml:"body"`
+	} `yaml:"request"`
+	Response struct {
+		Status int    `yaml:"status"`
+		Body   string `yaml:"body"`
+	} `yaml:"response"`
+}
+
+// replayServer replays the recorded interactions in order. Requests which don't match the next recorded
+// one fail the test.
+func replayServer(t *testing.T, path string) *httptest.Server {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var interactions []interaction
+	require.NoError(t, yaml.Unmarshal(content, &interactions))
+
+	var (
+		mu   sync.Mutex
+		next int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !assert.Less(t, next, len(interactions), "unexpected request %s %s", r.Method, r.URL) {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		recorded := interactions[next]
+		next++
+
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		assert.Equal(t, recorded.Request.Method, r.Method)
+		assert.Equal(t, recorded.Request.URI, r.URL.RequestURI())
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		if recorded.Request.Body != "" {
+			assert.JSONEq(t, recorded.Request.Body, string(body), "%s %s", r.Method, r.URL)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(recorded.Response.Status)
+		_, _ = io.WriteString(w, recorded.Response.Body)
+	}))
+
+	t.Cleanup(func() {
+		server.Close()
+		assert.Equal(t, len(interactions), next, "not every recorded request was sent")
+	})
+
+	return server
+}
+
+func TestGitea(t *testing.T) {
+	server := replayServer(t, "testdata/gitea.yaml")
+	gitea := &Gitea{BaseURL: server.URL, Owner: "owner", Repo: "repo", Token: "secret", Client: server.Client()}
+	ctx := context.Background()
+
+	requests, err := gitea.ListChangeRequests(ctx, "dependabot")
+	require.NoError(t, err)
+	assert.Equal(t, []*ChangeRequest{{
+		Number:       4,
+		URL:          "https://forgejo.example.com/owner/repo/pulls/4",
+		Title:        "Bump golang.org/x/text from 0.13.0 to 0.14.0",
+		Body:         "Bumps [golang.org/x/text](https://github.com/golang/text) from 0.13.0 to 0.14.0.",
+		SourceBranch: "dependabot/go_modules/golang.org/x/text-0.14.0",
+		TargetBranch: "main",
+		State:        StateOpen,
+		Labels:       []string{"dependencies"},
+		Author:       Author{Login: "dependabot", ID: 7},
+		// the same repository
+		HeadRepository: "owner/repo",
+		BaseRepository: "owner/repo",
+	}}, requests)
+
+	merged, err := gitea.GetChangeRequest(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, StateMerged, merged.State)
+
+	require.NoError(t, gitea.CreateBranch(ctx, "bundler-1", "main"))
+	require.NoError(t, gitea.CommitFiles(ctx, Commit{
+		Branch:      "bundler-1",
+		Message:     "Bundle updates",
+		AuthorName:  "Bundler",
+		AuthorEmail: "bundler@example.com",
+		Files: []File{
+			{Path: "go.mod", Content: []byte("module test\n")},
+			{Path: "hack/run.sh", Content: []byte("#!/bin/sh\n"), Executable: true, Added: true},
+			{Path: "docs/old notes.txt", Deleted: true},
+		},
+	}))
+
+	created, err := gitea.OpenChangeRequest(ctx, NewChangeRequest{
+		Title:        "Bundle",
+		Body:         "body",
+		SourceBranch: "bundler-1",
+		TargetBranch: "main",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, created.Number)
+	assert.Equal(t, "https://forgejo.example.com/owner/repo/pulls/5", created.URL)
+
+	require.NoError(t, gitea.AddLabels(ctx, created.Number, []string{"dependencies", "bundle"}))
+
+	err = gitea.CreateBranch(ctx, "bundler-2", "missing")
+	assert.ErrorContains(t, err, "status 404")
+	assert.ErrorContains(t, err, "The old branch does not exist")
+}
+
+func TestGiteaCommitSymlink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	}))
+	t.Cleanup(server.Close)
+
+	gitea := &Gitea{BaseURL: server.URL, Owner: "owner", Repo: "repo", Token: "secret", Client: server.Client()}
+
+	err := gitea.CommitFiles(context.Background(), Commit{
+		Branch: "bundler-1",
+		Files:  []File{{Path: "current", Content: []byte("v1"), Symlink: true, Added: true}},
+	})
+	assert.ErrorIs(t, err, errSymlink)
+}
@@ -0,0 +1,162 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v43/github"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/api"
+	"github.com/Skarlso/dependabot-bundler/pkg/logger"
+)
+
+const defaultNumberOfItemsPerPage = 100
+
+// errGitDataAPI is returned for the operations the bundler performs with the Git Data API itself on GitHub.
+var errGitDataAPI = errors.New("commits on GitHub are created with the Git Data API of the bundler")
+
+// GitHub implements Forge with the GitHub API. It's used for the change requests only, the commits are
+// created by the bundler.
+type GitHub struct {
+	Owner  string
+	Repo   string
+	Issues api.Issues
+	Pulls  api.PullRequests
+	Logger logger.Logger
+}
+
+var _ Forge = &GitHub{}
+
+// ListChangeRequests lists the open pull requests of the author. Pull requests which can't be fetched
+// are skipped.
+func (g *GitHub) ListChangeRequests(ctx context.Context, author string) ([]*ChangeRequest, error) {
+	// the pull request API can't filter by author, the issue API can
+	issues, _, err := g.Issues.ListByRepo(ctx, g.Owner, g.Repo, &github.IssueListByRepoOptions{
+		State:   "open",
+		Creator: author,
+		ListOptions: github.ListOptions{
+			PerPage: defaultNumberOfItemsPerPage,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	var result []*ChangeRequest
+
+	for _, issue := range issues {
+		if issue.PullRequestLinks == nil {
+			continue
+		}
+
+		pr, _, err := g.Pulls.Get(ctx, g.Owner, g.Repo, issue.GetNumber())
+		if err != nil {
+			g.Logger.Debug("failed to get pull request for number %d with error %s, skipping \n", issue.GetNumber(), err)
+
+			continue
+		}
+
+		request := fromPullRequest(pr)
+		request.Number = issue.GetNumber()
+		request.URL = issue.GetHTMLURL()
+		request.Body = issue.GetBody()
+		request.Labels = labelNames(issue.Labels)
+		result = append(result, request)
+	}
+
+	return result, nil
+}
+
+// GetChangeRequest returns the pull request with the number.
+func (g *GitHub) GetChangeRequest(ctx context.Context, number int) (*ChangeRequest, error) {
+	pr, _, err := g.Pulls.Get(ctx, g.Owner, g.Repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	request := fromPullRequest(pr)
+	request.Number = number
+
+	return request, nil
+}
+
+// CreateBranch isn't supported. On GitHub, the bundler creates the branches and commits itself with the Git
+// Data API, which also supports large and binary files, symbolic links, updating an existing bundle and the
+// rollback.
+func (g *GitHub) CreateBranch(context.Context, string, string) error {
+	return errGitDataAPI
+}
+
+// CommitFiles isn't supported, see CreateBranch.
+func (g *GitHub) CommitFiles(context.Context, Commit) error {
+	return errGitDataAPI
+}
+
+// OpenChangeRequest opens a pull request.
+func (g *GitHub) OpenChangeRequest(ctx context.Context, request NewChangeRequest) (*ChangeRequest, error) {
+	pr, _, err := g.Pulls.Create(ctx, g.Owner, g.Repo, &github.NewPullRequest{
+		Title:               &request.Title,
+		Head:                &request.SourceBranch,
+		Base:                &request.TargetBranch,
+		Body:                &request.Body,
+		MaintainerCanModify: github.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	return fromPullRequest(pr), nil
+}
+
+// AddLabels adds the labels to the pull request.
+func (g *GitHub) AddLabels(ctx context.Context, number int, labels []string) error {
+	if _, _, err := g.Issues.AddLabelsToIssue(ctx, g.Owner, g.Repo, number, labels); err != nil {
+		return fmt.Errorf("failed to add labels to issue: %w", err)
+	}
+
+	return nil
+}
+
+// ReferencePrefix returns the prefix of references to pull requests.
+func (g *GitHub) ReferencePrefix() string {
+	return "#"
+}
+
+func fromPullRequest(pr *github.PullRequest) *ChangeRequest {
+	state := StateOpen
+
+	switch {
+	case pr.MergedAt != nil:
+		state = StateMerged
+	case pr.GetState() == "closed":
+		state = StateClosed
+	}
+
+	return &ChangeRequest{
+		Number:       pr.GetNumber(),
+		URL:          pr.GetHTMLURL(),
+		Title:        pr.GetTitle(),
+		Body:         pr.GetBody(),
+		SourceBranch: pr.GetHead().GetRef(),
+		TargetBranch: pr.GetBase().GetRef(),
+		State:        state,
+		Labels:       labelNames(pr.Labels),
+		Author: Author{
+			Login: pr.GetUser().GetLogin(),
+			ID:    pr.GetUser().GetID(),
+			Bot:   pr.GetUser().GetType() == "Bot",
+		},
+		HeadRepository: pr.GetHead().GetRepo().GetFullName(),
+		BaseRepository: pr.GetBase().GetRepo().GetFullName(),
+	}
+}
+
+func labelNames(labels []*github.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.GetName())
+	}
+
+	return names
+}
@@ -0,0 +1,218 @@
+package forge
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultGitLabURL is the URL of gitlab.com.
+const DefaultGitLabURL = "https://gitlab.com"
+
+// GitLab implements Forge with the REST API of GitLab. Merge requests are addressed by their IID.
+type GitLab struct {
+	// BaseURL is the URL of the GitLab instance, for example https://gitlab.com.
+	BaseURL string
+	// Project is the path of the project, for example group/subgroup/project.
+	Project string
+	// Token is a personal, project or group access token with the api scope.
+	Token string
+	// Client is the HTTP client used for the requests. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+var _ Forge = &GitLab{}
+
+// mergeRequest is a merge request as returned by the API.
+type mergeRequest struct {
+	IID          int      `json:"iid"`
+	WebURL       string   `json:"web_url"`
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	SourceBranch string   `json:"source_branch"`
+	TargetBranch string   `json:"target_branch"`
+	State        string   `json:"state"`
+	Labels       []string `json:"labels"`
+	Author       struct {
+		ID       int64  `json:"id"`
+		Username string `json:"username"`
+	} `json:"author"`
+	SourceProjectID int64 `json:"source_project_id"`
+	TargetProjectID int64 `json:"target_project_id"`
+}
+
+// commitAction is an action of the commits API.
+type commitAction struct {
+	Action          string `json:"action"`
+	FilePath        string `json:"file_path"`
+	Content         string `json:"content,omitempty"`
+	Encoding        string `json:"encoding,omitempty"`
+	ExecuteFilemode bool   `json:"execute_filemode,omitempty"`
+}
+
+// ListChangeRequests lists the open merge requests of the author.
+func (g *GitLab) ListChangeRequests(ctx context.Context, author string) ([]*ChangeRequest, error) {
+	query := url.Values{}
+	query.Set("state", "opened")
+	query.Set("author_username", author)
+	query.Set("per_page", strconv.Itoa(defaultNumberOfItemsPerPage))
+
+	var mrs []mergeRequest
+	if err := g.do(ctx, http.MethodGet, "merge_requests?"+query.Encode(), nil, &mrs); err != nil {
+		return nil, fmt.Errorf("failed to list merge requests: %w", err)
+	}
+
+	result := make([]*ChangeRequest, 0, len(mrs))
+	for _, mr := range mrs {
+		result = append(result, mr.changeRequest())
+	}
+
+	return result, nil
+}
+
+// GetChangeRequest returns the merge request with the IID.
+func (g *GitLab) GetChangeRequest(ctx context.Context, number int) (*ChangeRequest, error) {
+	var mr mergeRequest
+	if err := g.do(ctx, http.MethodGet, fmt.Sprintf("merge_requests/%d", number), nil, &mr); err != nil {
+		return nil, fmt.Errorf("failed to get merge request: %w", err)
+	}
+
+	return mr.changeRequest(), nil
+}
+
+// CreateBranch creates the branch from the head of base.
+func (g *GitLab) CreateBranch(ctx context.Context, branch, base string) error {
+	body := map[string]string{"branch": branch, "ref": base}
+	if err := g.do(ctx, http.MethodPost, "repository/branches", body, nil); err != nil {
+		return fmt.Errorf("failed to create branch: %w", err)
+	}
+
+	return nil
+}
+
+// CommitFiles creates a commit with the files on top of the branch.
+func (g *GitLab) CommitFiles(ctx context.Context, commit Commit) error {
+	actions := make([]commitAction, 0, len(commit.Files))
+
+	for _, file := range commit.Files {
+		if file.Symlink && !file.Deleted {
+			return fmt.Errorf("failed to commit %s: %w", file.Path, errSymlink)
+		}
+
+		action := commitAction{FilePath: file.Path}
+
+		switch {
+		case file.Deleted:
+			action.Action = "delete"
+		case file.Added:
+			action.Action = "create"
+		default:
+			action.Action = "update"
+		}
+
+		if !file.Deleted {
+			action.Content = base64.StdEncoding.EncodeToString(file.Content)
+			action.Encoding = "base64"
+		}
+
+		actions = append(actions, action)
+
+		// execute_filemode is only honoured by the chmod action
+		if !file.Deleted && file.Executable {
+			actions = append(actions, commitAction{Action: "chmod", FilePath: file.Path, ExecuteFilemode: true})
+		}
+	}
+
+	body := map[string]any{
+		"branch":         commit.Branch,
+		"commit_message": commit.Message,
+		"author_name":    commit.AuthorName,
+		"author_email":   commit.AuthorEmail,
+		"actions":        actions,
+	}
+
+	if err := g.do(ctx, http.MethodPost, "repository/commits", body, nil); err != nil {
+		return fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	return nil
+}
+
+// OpenChangeRequest opens a merge request.
+func (g *GitLab) OpenChangeRequest(ctx context.Context, request NewChangeRequest) (*ChangeRequest, error) {
+	body := map[string]string{
+		"source_branch": request.SourceBranch,
+		"target_branch": request.TargetBranch,
+		"title":         request.Title,
+		"description":   request.Body,
+	}
+
+	var mr mergeRequest
+	if err := g.do(ctx, http.MethodPost, "merge_requests", body, &mr); err != nil {
+		return nil, fmt.Errorf("failed to create merge request: %w", err)
+	}
+
+	return mr.changeRequest(), nil
+}
+
+// AddLabels adds the labels to the merge request.
+func (g *GitLab) AddLabels(ctx context.Context, number int, labels []string) error {
+	body := map[string]string{"add_labels": strings.Join(labels, ",")}
+	if err := g.do(ctx, http.MethodPut, fmt.Sprintf("merge_requests/%d", number), body, nil); err != nil {
+		return fmt.Errorf("failed to add labels to merge request: %w", err)
+	}
+
+	return nil
+}
+
+// ReferencePrefix returns the prefix of references to merge requests. `#` refers to issues on GitLab.
+func (g *GitLab) ReferencePrefix() string {
+	return "!"
+}
+
+// do sends a request to the path below the project.
+func (g *GitLab) do(ctx context.Context, method, path string, body, result any) error {
+	baseURL := g.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultGitLabURL
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v4/projects/%s/%s",
+		strings.TrimSuffix(baseURL, "/"), url.PathEscape(g.Project), path)
+	header := http.Header{}
+	header.Set("PRIVATE-TOKEN", g.Token)
+
+	return doJSON(ctx, g.Client, method, endpoint, header, body, result)
+}
+
+func (mr mergeRequest) changeRequest() *ChangeRequest {
+	state := mr.State
+
+	switch mr.State {
+	case "opened":
+		state = StateOpen
+	case "closed", "locked":
+		state = StateClosed
+	}
+
+	return &ChangeRequest{
+		Number:       mr.IID,
+		URL:          mr.WebURL,
+		Title:        mr.Title,
+		Body:         mr.Description,
+		SourceBranch: mr.SourceBranch,
+		TargetBranch: mr.TargetBranch,
+		State:        state,
+		Labels:       mr.Labels,
+		Author: Author{
+			Login: mr.Author.Username,
+			ID:    mr.Author.ID,
+		},
+		HeadRepository: strconv.FormatInt(mr.SourceProjectID, 10),
+		BaseRepository: strconv.FormatInt(mr.TargetProjectID, 10),
+	}
+}
@@ -0,0 +1,270 @@
+package forge
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const projectPrefix = "/api/v4/projects/test%2Ftest/"
+
+// gitLabServer is an in-memory stand-in for the parts of the GitLab API the forge uses.
+type gitLabServer struct {
+	mu       sync.Mutex
+	mrs      []mergeRequest
+	branches map[string]string
+	commits  []map[string]any
+	tokens   []string
+}
+
+func newGitLabServer(t *testing.T) (*gitLabServer, *GitLab) {
+	t.Helper()
+
+	fake := &gitLabServer{branches: map[string]string{"main": ""}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	return fake, &GitLab{BaseURL: server.URL + "/", Project: "test/test", Token: "secret", Client: server.Client()}
+}
+
+func (s *gitLabServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = append(s.tokens, r.Header.Get("PRIVATE-TOKEN"))
+
+	path := r.URL.EscapedPath()
+	if !strings.HasPrefix(path, projectPrefix) {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	path = strings.TrimPrefix(path, projectPrefix)
+
+	var body map[string]any
+	if r.Body != nil && r.Method != http.MethodGet {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	switch {
+	case r.Method == http.MethodGet && path == "merge_requests":
+		s.listMergeRequests(w, r)
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "merge_requests/"):
+		if mr := s.find(strings.TrimPrefix(path, "merge_requests/")); mr != nil {
+			writeJSON(w, http.StatusOK, mr)
+		} else {
+			http.NotFound(w, r)
+		}
+	case r.Method == http.MethodPut && strings.HasPrefix(path, "merge_requests/"):
+		mr := s.find(strings.TrimPrefix(path, "merge_requests/"))
+		if mr == nil {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		mr.Labels = append(mr.Labels, strings.Split(body["add_labels"].(string), ",")...)
+		writeJSON(w, http.StatusOK, mr)
+	case r.Method == http.MethodPost && path == "merge_requests":
+		s.createMergeRequest(w, body)
+	case r.Method == http.MethodPost && path == "repository/branches":
+		if _, ok := s.branches[body["ref"].(string)]; !ok {
+			http.Error(w, `{"message":"Invalid reference name"}`, http.StatusBadRequest)
+
+			return
+		}
+
+		s.branches[body["branch"].(string)] = ""
+		writeJSON(w, http.StatusCreated, body)
+	case r.Method == http.MethodPost && path == "repository/commits":
+		if _, ok := s.branches[body["branch"].(string)]; !ok {
+			http.Error(w, `{"message":"You can only create or edit files when you are on a branch"}`, http.StatusBadRequest)
+
+			return
+		}
+
+		s.commits = append(s.commits, body)
+		writeJSON(w, http.StatusCreated, map[string]string{"id": strconv.Itoa(len(s.commits))})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *gitLabServer) listMergeRequests(w http.ResponseWriter, r *http.Request) {
+	result := []mergeRequest{}
+
+	for _, mr := range s.mrs {
+		if r.URL.Query().Get("state") == mr.State && r.URL.Query().Get("author_username") == "dependabot" {
+			result = append(result, mr)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *gitLabServer) createMergeRequest(w http.ResponseWriter, body map[string]any) {
+	mr := mergeRequest{
+		IID:          len(s.mrs) + 1,
+		WebURL:       "https://gitlab.com/test/test/-/merge_requests/" + strconv.Itoa(len(s.mrs)+1),
+		Title:        body["title"].(string),
+		Description:  body["description"].(string),
+		SourceBranch: body["source_branch"].(string),
+		TargetBranch: body["target_branch"].(string),
+		State:        "opened",
+		Labels:       []string{},
+	}
+	s.mrs = append(s.mrs, mr)
+
+	writeJSON(w, http.StatusCreated, mr)
+}
+
+func (s *gitLabServer) find(iid string) *mergeRequest {
+	for i := range s.mrs {
+		if strconv.Itoa(s.mrs[i].IID) == iid {
+			return &s.mrs[i]
+		}
+	}
+
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func TestGitLabListChangeRequests(t *testing.T) {
+	fake, gitlab := newGitLabServer(t)
+	fake.mrs = []mergeRequest{
+		{
+			IID: 1, WebURL: "https://gitlab.com/test/test/-/merge_requests/1", Title: "Bump a from 1.0.0 to 1.0.1",
+			Description: "Bumps [a](a) from 1.0.0 to 1.0.1.", SourceBranch: "dependabot-go_modules-.-a-1.0.1",
+			TargetBranch: "main", State: "opened", Labels: []string{"dependencies"},
+		},
+		{IID: 2, State: "merged"},
+	}
+	fake.mrs[0].Author.ID, fake.mrs[0].Author.Username = 12, "dependabot"
+	fake.mrs[0].SourceProjectID, fake.mrs[0].TargetProjectID = 42, 42
+
+	requests, err := gitlab.ListChangeRequests(context.Background(), "dependabot")
+	require.NoError(t, err)
+	assert.Equal(t, []*ChangeRequest{{
+		Number:       1,
+		URL:          "https://gitlab.com/test/test/-/merge_requests/1",
+		Title:        "Bump a from 1.0.0 to 1.0.1",
+		Body:         "Bumps [a](a) from 1.0.0 to 1.0.1.",
+		SourceBranch: "dependabot-go_modules-.-a-1.0.1",
+		TargetBranch: "main",
+		State:        StateOpen,
+		Labels:       []string{"dependencies"},
+		Author:       Author{Login: "dependabot", ID: 12},
+		// the same project
+		HeadRepository: "42",
+		BaseRepository: "42",
+	}}, requests)
+
+	merged, err := gitlab.GetChangeRequest(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, StateMerged, merged.State)
+
+	_, err = gitlab.GetChangeRequest(context.Background(), 3)
+	assert.ErrorContains(t, err, "status 404")
+	assert.Equal(t, []string{"secret", "secret", "secret"}, fake.tokens)
+}
+
+func TestGitLabOpenChangeRequest(t *testing.T) {
+	fake, gitlab := newGitLabServer(t)
+	ctx := context.Background()
+
+	require.NoError(t, gitlab.CreateBranch(ctx, "bundler-1", "main"))
+	require.NoError(t, gitlab.CommitFiles(ctx, Commit{
+		Branch:      "bundler-1",
+		Message:     "Bundle updates",
+		AuthorName:  "Bundler",
+		AuthorEmail: "bundler@example.com",
+		Files: []File{
+			{Path: "go.mod", Content: []byte("module test\n")},
+			{Path: "hack/run.sh", Content: []byte("#!/bin/sh\n"), Executable: true, Added: true},
+			{Path: "old.txt", Deleted: true},
+		},
+	}))
+
+	created, err := gitlab.OpenChangeRequest(ctx, NewChangeRequest{
+		Title:        "Bundle",
+		Body:         "body",
+		SourceBranch: "bundler-1",
+		TargetBranch: "main",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.Number)
+	assert.Equal(t, StateOpen, created.State)
+
+	require.NoError(t, gitlab.AddLabels(ctx, created.Number, []string{"dependencies", "bundle"}))
+	assert.Equal(t, []string{"dependencies", "bundle"}, fake.mrs[0].Labels)
+
+	require.Len(t, fake.commits, 1)
+	commit := fake.commits[0]
+	assert.Equal(t, "bundler-1", commit["branch"])
+	assert.Equal(t, "Bundle updates", commit["commit_message"])
+	assert.Equal(t, "Bundler", commit["author_name"])
+	assert.Equal(t, "bundler@example.com", commit["author_email"])
+	assert.Equal(t, []any{
+		map[string]any{
+			"action":    "update",
+			"file_path": "go.mod",
+			"content":   base64.StdEncoding.EncodeToString([]byte("module test\n")),
+			"encoding":  "base64",
+		},
+		map[string]any{
+			"action":    "create",
+			"file_path": "hack/run.sh",
+			"content":   base64.StdEncoding.EncodeToString([]byte("#!/bin/sh\n")),
+			"encoding":  "base64",
+		},
+		map[string]any{
+			"action":           "chmod",
+			"file_path":        "hack/run.sh",
+			"execute_filemode": true,
+		},
+		map[string]any{
+			"action":    "delete",
+			"file_path": "old.txt",
+		},
+	}, commit["actions"])
+}
+
+func TestGitLabErrors(t *testing.T) {
+	_, gitlab := newGitLabServer(t)
+	ctx := context.Background()
+
+	assert.ErrorContains(t, gitlab.CreateBranch(ctx, "bundler-1", "missing"), "Invalid reference name")
+	assert.ErrorContains(t, gitlab.CommitFiles(ctx, Commit{Branch: "missing"}), "failed to create commit")
+	assert.ErrorContains(t, gitlab.AddLabels(ctx, 1, []string{"a"}), "failed to add labels to merge request")
+}
+
+func TestGitLabCommitSymlink(t *testing.T) {
+	fake, gitlab := newGitLabServer(t)
+
+	err := gitlab.CommitFiles(context.Background(), Commit{
+		Branch: "main",
+		Files:  []File{{Path: "current", Content: []byte("v1"), Symlink: true}},
+	})
+	assert.ErrorIs(t, err, errSymlink)
+	assert.ErrorContains(t, err, "failed to commit current")
+	assert.Empty(t, fake.commits)
+}
@@ -0,0 +1,182 @@
+package pkg
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Group is a bundle of its own. Each group opens or updates a separate PR with the updates which match its
+// rules. A PR which matches several groups is bundled by the first one.
+type Group struct {
+	// Name identifies the group. It's recorded in a hidden marker of the bundle PR and is part of the branch name.
+	Name string `yaml:"name"`
+	// Filter, MaxUpdateLevel and ExcludeMajor select the updates of the group, like the fields of Config.
+	Filter         Filter `yaml:"filter"`
+	MaxUpdateLevel string `yaml:"max-update-level"`
+	ExcludeMajor   bool   `yaml:"exclude-major"`
+	// PRTitle and Labels default to the ones of the Config if empty.
+	PRTitle string   `yaml:"title"`
+	Labels  []string `yaml:"labels"`
+	// Branch is the prefix of the branches of the group. It is followed by a unix timestamp.
+	// Defaults to `bundler-<name>-`.
+	Branch string `yaml:"branch"`
+}
+
+var (
+	// groupNameRegexp restricts group names to characters which can be used in branch names.
+	groupNameRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+	// groupRegexp matches the hidden marker with the name of the group of a bundle PR.
+	groupRegexp = regexp.MustCompile(`<!-- dependabot-bundler-group: (\S+) -->`)
+	// timestampRegexp matches the unix timestamp which follows the prefix of a branch of the bundler.
+	timestampRegexp = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// groupMarker returns the hidden marker which records the group of a bundle PR.
+func groupMarker(name string) string {
+	return fmt.Sprintf("<!-- dependabot-bundler-group: %s -->", name)
+}
+
+// parseGroup returns the group recorded in the body of a bundle PR, or an empty string if there is none.
+func parseGroup(body string) string {
+	matches := groupRegexp.FindStringSubmatch(body)
+	if matches == nil {
+		return ""
+	}
+
+	return matches[1]
+}
+
+// validate checks the filter, the bots and the groups of the configuration.
+func (c Config) validate() error {
+	if err := c.Filter.Validate(); err != nil {
+		return fmt.Errorf("invalid filter: %w", err)
+	}
+
+	for _, bot := range c.Bots {
+		if err := bot.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if !c.onGitHub() && (c.CloseSuperseded != CloseNever || c.CommentOnPRs) {
+		return fmt.Errorf("closing and commenting on the bundled PRs is only supported on GitHub")
+	}
+
+	if !c.onGitHub() && c.RequireVerifiedCommits {
+		return fmt.Errorf("requiring verified commits is only supported on GitHub")
+	}
+
+	names := make(map[string]struct{}, len(c.Groups))
+
+	for _, group := range c.Groups {
+		if err := group.Validate(); err != nil {
+			return err
+		}
+
+		if _, ok := names[group.Name]; ok {
+			return fmt.Errorf("duplicate group name %s", group.Name)
+		}
+
+		names[group.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// Validate checks the name and the filter of the group.
+func (g Group) Validate() error {
+	if !groupNameRegexp.MatchString(g.Name) {
+		return fmt.Errorf("invalid group name %q, must only contain letters, digits, '.', '_' and '-'", g.Name)
+	}
+
+	if err := g.Filter.Validate(); err != nil {
+		return fmt.Errorf("invalid filter of group %s: %w", g.Name, err)
+	}
+
+	return nil
+}
+
+// forGroup returns a copy of the bundler which bundles the updates of the group. The copy has a rollback
+// of its own, so a failing group doesn't undo the bundles of the previous ones.
+func (n *Bundler) forGroup(group Group) *Bundler {
+	bundler := *n
+	bundler.undo = &rollback{}
+	bundler.Filter = group.Filter
+	bundler.MaxUpdateLevel = group.MaxUpdateLevel
+	bundler.ExcludeMajor = group.ExcludeMajor
+	bundler.group = group.Name
+	bundler.branchPrefix = group.prefix()
+
+	if group.PRTitle != "" {
+		bundler.PRTitle = group.PRTitle
+	}
+
+	if len(group.Labels) > 0 {
+		bundler.Labels = group.Labels
+	}
+
+	return &bundler
+}
+
+// prefix returns the prefix of the branches of the group.
+func (g Group) prefix() string {
+	if g.Branch == "" {
+		return branchPrefix + g.Name + "-"
+	}
+
+	return g.Branch
+}
+
+// branchPrefixes returns the prefixes of the branches the bundler creates, including the ones of the groups.
+func (c Config) branchPrefixes() []string {
+	prefixes := []string{branchPrefix}
+
+	for _, group := range c.Groups {
+		if group.Branch != "" {
+			prefixes = append(prefixes, group.Branch)
+		}
+	}
+
+	return prefixes
+}
+
+// bundleBranch returns the time a branch of the bundler was created at. It reports false for branches which
+// don't consist of the prefix of the bundler or of one of the groups followed by a unix timestamp.
+func (c Config) bundleBranch(branch string) (time.Time, bool) {
+	prefixes := []string{branchPrefix}
+	for _, group := range c.Groups {
+		prefixes = append(prefixes, group.prefix())
+	}
+
+	for _, prefix := range prefixes {
+		timestamp, ok := strings.CutPrefix(branch, prefix)
+		if !ok || !timestampRegexp.MatchString(timestamp) {
+			continue
+		}
+
+		seconds, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		return time.Unix(seconds, 0).UTC(), true
+	}
+
+	return time.Time{}, false
+}
+
+// unclaimed returns the candidates which haven't been bundled by a previous group.
+func unclaimed(candidates []candidate, claimed map[int]struct{}) []candidate {
+	var result []candidate
+
+	for _, c := range candidates {
+		if _, ok := claimed[c.update.Number]; !ok {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,203 @@
+package pkg_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-github/v43/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Skarlso/dependabot-bundler/pkg"
+)
+
+func TestBundlerGroups(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.Groups = []pkg.Group{
+		{
+			Name:    "actions",
+			Filter:  pkg.Filter{IncludeEcosystems: []string{"github_actions"}},
+			PRTitle: "Bundled action updates",
+			Labels:  []string{"ci"},
+		},
+		{
+			Name:   "go",
+			Filter: pkg.Filter{IncludeEcosystems: []string{"go_modules"}},
+		},
+		{
+			Name: "rest",
+		},
+	}
+	f.issues.ListByRepoReturns([]*github.Issue{
+		{Number: github.Int(1), PullRequestLinks: &github.PullRequestLinks{}},
+		{Number: github.Int(2), PullRequestLinks: &github.PullRequestLinks{}},
+	}, &github.Response{}, nil)
+	f.pulls.GetReturnsOnCall(0, &github.PullRequest{
+		Title: github.String("Bump actions/checkout from 3 to 4"),
+		Head:  &github.PullRequestBranch{Ref: github.String("dependabot/github_actions/actions/checkout-4")},
+	}, nil, nil)
+	f.pulls.GetReturnsOnCall(1, &github.PullRequest{
+		Title: github.String("Bump github.com/test/test from 1.0.0 to 1.1.0"),
+		Head:  &github.PullRequestBranch{Ref: github.String("dependabot/go_modules/github.com/test/test-1.1.0")},
+	}, nil, nil)
+	// the go group already has a bundle, the bundle without a group is ignored
+	f.pulls.ListReturns([]*github.PullRequest{
+		{
+			Number: github.Int(3),
+			Body:   github.String("<!-- dependabot-bundler -->"),
+			Head:   bundleHead("bundler-12345"),
+		},
+		{
+			Number: github.Int(4),
+			Body:   github.String("<!-- dependabot-bundler -->\n<!-- dependabot-bundler-group: go -->"),
+			Head:   bundleHead("bundler-go-12345"),
+		},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	require.Equal(t, 2, f.updater.UpdateCallCount())
+
+	require.Equal(t, 1, f.git.CreateRefCallCount())
+	_, _, _, ref := f.git.CreateRefArgsForCall(0)
+	assert.Regexp(t, `^refs/heads/bundler-actions-\d+$`, ref.GetRef())
+	require.Equal(t, 1, f.pulls.CreateCallCount())
+	_, _, _, newPR := f.pulls.CreateArgsForCall(0)
+	assert.Equal(t, "Bundled action updates", newPR.GetTitle())
+	assert.Contains(t, newPR.GetBody(), "<!-- dependabot-bundler-prs: 1 -->")
+	assert.Contains(t, newPR.GetBody(), "<!-- dependabot-bundler-group: actions -->")
+	_, _, _, _, labels := f.issues.AddLabelsToIssueArgsForCall(0)
+	assert.Equal(t, []string{"ci"}, labels)
+
+	require.Equal(t, 1, f.pulls.EditCallCount())
+	_, _, _, number, pr := f.pulls.EditArgsForCall(0)
+	assert.Equal(t, 4, number)
+	assert.Contains(t, pr.GetBody(), "<!-- dependabot-bundler-prs: 2 -->")
+	assert.Contains(t, pr.GetBody(), "<!-- dependabot-bundler-group: go -->")
+	_, _, _, _, labels = f.issues.AddLabelsToIssueArgsForCall(1)
+	assert.Equal(t, []string{"label1", "label2"}, labels)
+}
+
+func TestBundlerUngrouped(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.BundleUngrouped = true
+	bundler.Filter = pkg.Filter{ExcludeDependencies: []string{"k8s.io/*"}}
+	bundler.Groups = []pkg.Group{
+		{Name: "actions", Filter: pkg.Filter{IncludeEcosystems: []string{"github_actions"}}},
+	}
+	f.issues.ListByRepoReturns([]*github.Issue{
+		{Number: github.Int(1), PullRequestLinks: &github.PullRequestLinks{}},
+		{Number: github.Int(2), PullRequestLinks: &github.PullRequestLinks{}},
+		{
+			Number:           github.Int(3),
+			Body:             github.String("Bumps [k8s.io/api](https://github.com/kubernetes/api)"),
+			PullRequestLinks: &github.PullRequestLinks{},
+		},
+	}, &github.Response{}, nil)
+	f.pulls.GetReturnsOnCall(0, &github.PullRequest{
+		Title: github.String("Bump actions/checkout from 3 to 4"),
+		Head:  &github.PullRequestBranch{Ref: github.String("dependabot/github_actions/actions/checkout-4")},
+	}, nil, nil)
+	f.pulls.GetReturnsOnCall(1, &github.PullRequest{
+		Title: github.String("Bump github.com/test/test from 1.0.0 to 1.1.0"),
+		Head:  &github.PullRequestBranch{Ref: github.String("dependabot/go_modules/github.com/test/test-1.1.0")},
+	}, nil, nil)
+	f.pulls.GetReturnsOnCall(2, &github.PullRequest{
+		Title: github.String("Bump k8s.io/api from 0.28.0 to 0.29.0"),
+		Head:  &github.PullRequestBranch{Ref: github.String("dependabot/go_modules/k8s.io/api-0.29.0")},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+
+	require.Equal(t, 2, f.pulls.CreateCallCount())
+	_, _, _, actions := f.pulls.CreateArgsForCall(0)
+	assert.Contains(t, actions.GetBody(), "<!-- dependabot-bundler-prs: 1 -->")
+	assert.Contains(t, actions.GetBody(), "<!-- dependabot-bundler-group: actions -->")
+	// the rest is bundled like without groups, with the top-level filter
+	_, _, _, rest := f.pulls.CreateArgsForCall(1)
+	assert.Contains(t, rest.GetBody(), "<!-- dependabot-bundler-prs: 2 -->")
+	assert.NotContains(t, rest.GetBody(), "dependabot-bundler-group")
+	_, _, _, ref := f.git.CreateRefArgsForCall(1)
+	assert.Regexp(t, `^refs/heads/bundler-\d+$`, ref.GetRef())
+}
+
+func TestBundlerGroupsRollbackFailedGroup(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.CloseSuperseded = pkg.CloseOnOpen
+	bundler.Groups = []pkg.Group{
+		{Name: "actions", Filter: pkg.Filter{IncludeEcosystems: []string{"github_actions"}}},
+		{Name: "go"},
+	}
+	f.issues.ListByRepoReturns([]*github.Issue{
+		{Number: github.Int(1), PullRequestLinks: &github.PullRequestLinks{}},
+		{Number: github.Int(2), PullRequestLinks: &github.PullRequestLinks{}},
+	}, &github.Response{}, nil)
+	f.pulls.GetReturnsOnCall(0, &github.PullRequest{
+		Title: github.String("Bump actions/checkout from 3 to 4"),
+		Head:  &github.PullRequestBranch{Ref: github.String("dependabot/github_actions/actions/checkout-4")},
+	}, nil, nil)
+	f.pulls.GetReturnsOnCall(1, &github.PullRequest{
+		Title: github.String("Bump github.com/test/test from 1.0.0 to 1.1.0"),
+		Head:  &github.PullRequestBranch{Ref: github.String("dependabot/go_modules/github.com/test/test-1.1.0")},
+	}, nil, nil)
+	f.pulls.CreateReturnsOnCall(0, &github.PullRequest{Number: github.Int(10)}, nil, nil)
+	f.pulls.CreateReturnsOnCall(1, &github.PullRequest{Number: github.Int(11)}, nil, nil)
+	f.issues.AddLabelsToIssueReturnsOnCall(1, nil, nil, errors.New("labels failed"))
+
+	assert.ErrorContains(t, bundler.Bundle(), "failed to bundle group go")
+
+	// the bundle of the actions group is kept, it already superseded PR #1
+	require.Equal(t, 1, f.git.DeleteRefCallCount())
+	_, _, _, ref := f.git.DeleteRefArgsForCall(0)
+	assert.Regexp(t, `^refs/heads/bundler-go-\d+$`, ref)
+
+	var closed []int
+
+	for i := 0; i < f.pulls.EditCallCount(); i++ {
+		_, _, _, number, pr := f.pulls.EditArgsForCall(i)
+		if pr.GetState() == "closed" {
+			closed = append(closed, number)
+		}
+	}
+
+	assert.Equal(t, []int{1, 11}, closed)
+}
+
+func TestBundlerInvalidGroups(t *testing.T) {
+	testCases := []struct {
+		name   string
+		groups []pkg.Group
+		err    string
+	}{
+		{
+			name:   "empty name",
+			groups: []pkg.Group{{}},
+			err:    `invalid group name ""`,
+		},
+		{
+			name:   "invalid name",
+			groups: []pkg.Group{{Name: "go patch"}},
+			err:    `invalid group name "go patch"`,
+		},
+		{
+			name:   "duplicate name",
+			groups: []pkg.Group{{Name: "go"}, {Name: "go"}},
+			err:    "duplicate group name go",
+		},
+		{
+			name:   "invalid filter",
+			groups: []pkg.Group{{Name: "go", Filter: pkg.Filter{IncludeDependencies: []string{"re:("}}}},
+			err:    "invalid filter of group go",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bundler, f := newTestBundler()
+			bundler.Groups = tc.groups
+
+			assert.ErrorContains(t, bundler.Bundle(), tc.err)
+			assert.Equal(t, 0, f.issues.ListByRepoCallCount())
+		})
+	}
+}
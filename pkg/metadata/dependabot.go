@@ -0,0 +1,86 @@
+package metadata
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// Bumps [github.com/aws/aws-sdk-go-v2](https://github.com/aws/aws-sdk-go-v2) from 1.16.4 to 1.16.5.
+	bodyNameRegexp    = regexp.MustCompile(`Bumps \[([^\]]*)\]`)
+	bodyVersionRegexp = regexp.MustCompile(`Bumps \[[^\]]*\]\S* from (\S+) to (\S+?)\.?(\s|$)`)
+	// chore(deps): Bump golang.org/x/sys from 0.0.1 to 0.1.0 in /hack/tools
+	titleRegexp = regexp.MustCompile(`[Bb]ump (\S+) from (\S+) to (\S+)(?: in (.*))?`)
+	// update-type: version-update:semver-minor
+	updateTypeRegexp = regexp.MustCompile(`update-type: version-update:semver-(major|minor|patch)`)
+	// dependabot-go_modules-.-github.com-aws-aws-sdk-go-v2-1.16.5, as created by dependabot-gitlab
+	gitlabBranchRegexp = regexp.MustCompile(`^dependabot-([a-z_]+)-`)
+)
+
+// ParseDependabot extracts the update information out of a Dependabot pull request.
+// The branch is something like this: dependabot/go_modules/github.com/aws/aws-sdk-go-v2/service/ssm-1.27.0.
+// Merge requests of dependabot-gitlab use dashes instead: dependabot-go_modules-.-github.com-aws-aws-sdk-go-v2-1.27.0.
+// Information which can't be found is left empty.
+func ParseDependabot(number int, url, title, body, branch string) Update {
+	update := Update{
+		Number:    number,
+		URL:       url,
+		Directory: "/",
+	}
+
+	if split := strings.Split(branch, "/"); len(split) > 2 && split[0] == "dependabot" {
+		update.Ecosystem = split[1]
+	} else if matches := gitlabBranchRegexp.FindStringSubmatch(branch); matches != nil {
+		update.Ecosystem = matches[1]
+	}
+
+	if matches := titleRegexp.FindStringSubmatch(title); matches != nil {
+		update.Dependency, update.From, update.To = matches[1], matches[2], matches[3]
+
+		if matches[4] != "" {
+			update.Directory = strings.TrimSpace(matches[4])
+		}
+	}
+
+	// the body contains the full name of the dependency, the title might shorten it
+	if matches := bodyNameRegexp.FindStringSubmatch(body); matches != nil {
+		update.Dependency = matches[1]
+	}
+
+	if matches := bodyVersionRegexp.FindStringSubmatch(body); matches != nil {
+		update.From, update.To = matches[1], matches[2]
+	}
+
+	update.UpdateType = ParseUpdateType(body)
+
+	return update
+}
+
+// ParseUpdateType returns the level of the update-type trailer Dependabot writes into its commit messages, or
+// LevelUnknown if there is none.
+func ParseUpdateType(message string) string {
+	matches := updateTypeRegexp.FindStringSubmatch(message)
+	if matches == nil {
+		return LevelUnknown
+	}
+
+	return matches[1]
+}
+
+// AsDependabot describes the update the way Dependabot does in the title, body and branch of its pull requests.
+// The updaters understand this format, so it's used to apply the updates of other bots.
+func (u Update) AsDependabot() (title, body, branch string) {
+	// Dependabot leaves out the v prefix of the versions, the GitHub Actions updater adds it back
+	from, to := strings.TrimPrefix(u.From, "v"), strings.TrimPrefix(u.To, "v")
+
+	title = fmt.Sprintf("Bump %s from %s to %s", u.Dependency, from, to)
+	if u.Directory != "" && u.Directory != "/" {
+		title += " in " + u.Directory
+	}
+
+	body = fmt.Sprintf("Bumps [%s](%s) from %s to %s.", u.Dependency, u.Dependency, from, to)
+	branch = fmt.Sprintf("dependabot/%s/%s-%s", u.Ecosystem, u.Dependency, to)
+
+	return title, body, branch
+}
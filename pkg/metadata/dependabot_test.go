@@ -0,0 +1,134 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDependabot(t *testing.T) {
+	update := ParseDependabot(
+		1,
+		"https://github.com/test/test/pull/1",
+		"chore(deps): Bump github.com/aws/aws-sdk-go-v2/service/ssm from 1.26.0 to 1.27.0 in /hack/tools",
+		"Bumps [github.com/aws/aws-sdk-go-v2/service/ssm](https://github.com/aws/aws-sdk-go-v2) from 1.26.0 to 1.27.0.\n- [Release notes](https://github.com/aws/aws-sdk-go-v2/releases)",
+		"dependabot/go_modules/hack/tools/github.com/aws/aws-sdk-go-v2/service/ssm-1.27.0",
+	)
+	assert.Equal(t, Update{
+		Number:     1,
+		URL:        "https://github.com/test/test/pull/1",
+		Dependency: "github.com/aws/aws-sdk-go-v2/service/ssm",
+		Ecosystem:  "go_modules",
+		Directory:  "/hack/tools",
+		From:       "1.26.0",
+		To:         "1.27.0",
+	}, update)
+	assert.Equal(t, LevelMinor, update.Level())
+}
+
+func TestParseDependabotFromBodyOnly(t *testing.T) {
+	update := ParseDependabot(
+		2,
+		"",
+		"Title",
+		"Bumps [actions/checkout](https://github.com/actions/checkout) from 2 to 3",
+		"dependabot/github_actions/actions/checkout-3",
+	)
+	assert.Equal(t, Update{
+		Number:     2,
+		Dependency: "actions/checkout",
+		Ecosystem:  "github_actions",
+		Directory:  "/",
+		From:       "2",
+		To:         "3",
+	}, update)
+	assert.Equal(t, LevelMajor, update.Level())
+}
+
+func TestParseDependabotGitLab(t *testing.T) {
+	update := ParseDependabot(
+		5,
+		"https://gitlab.com/test/test/-/merge_requests/5",
+		"Bump github.com/stretchr/testify from 1.7.0 to 1.7.1",
+		"Bumps [github.com/stretchr/testify](https://github.com/stretchr/testify) from 1.7.0 to 1.7.1.",
+		"dependabot-go_modules-.-github.com-stretchr-testify-1.7.1",
+	)
+	assert.Equal(t, Update{
+		Number:     5,
+		URL:        "https://gitlab.com/test/test/-/merge_requests/5",
+		Dependency: "github.com/stretchr/testify",
+		Ecosystem:  "go_modules",
+		Directory:  "/",
+		From:       "1.7.0",
+		To:         "1.7.1",
+	}, update)
+}
+
+func TestParseDependabotUnknown(t *testing.T) {
+	update := ParseDependabot(3, "", "Title", "Body", "feature/branch")
+	assert.Equal(t, Update{Number: 3, Directory: "/"}, update)
+}
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want string
+	}{
+		{from: "1.0.0", to: "1.0.1", want: LevelPatch},
+		{from: "v1.0.0", to: "v1.2.0", want: LevelMinor},
+		{from: "1.9.0", to: "2.0.0", want: LevelMajor},
+		{from: "2", to: "3", want: LevelMajor},
+		{from: "1.0.0-rc.1", to: "1.0.0", want: LevelPatch},
+		{from: "0.0.0-20200323222414-85ca7c5b95cd", to: "0.1.0", want: LevelMinor},
+		{from: "c0d1093b783f7ad0c445884b01da0215b2da29ee", to: "1.5.0", want: LevelUnknown},
+		{from: "", to: "1.0.0", want: LevelUnknown},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Level(tt.from, tt.to), "%s -> %s", tt.from, tt.to)
+	}
+}
+
+func TestParseDependabotWithoutVersions(t *testing.T) {
+	update := ParseDependabot(4, "", "Title", "Bumps [github.com/test/test](github.com/test/test)", "")
+	assert.Equal(t, Update{Number: 4, Dependency: "github.com/test/test", Directory: "/"}, update)
+}
+
+func TestParseDependabotUpdateType(t *testing.T) {
+	body := "Bumps [actions/checkout](https://github.com/actions/checkout) from 3 to 4.\n" +
+		"updated-dependencies:\n- dependency-name: actions/checkout\n  update-type: version-update:semver-minor\n"
+	update := ParseDependabot(4, "", "", body, "")
+	assert.Equal(t, "minor", update.UpdateType)
+	assert.Equal(t, LevelMinor, update.Level())
+}
+
+func TestExceeds(t *testing.T) {
+	assert.True(t, Exceeds(LevelMajor, LevelMinor))
+	assert.True(t, Exceeds(LevelMinor, LevelPatch))
+	assert.False(t, Exceeds(LevelMinor, LevelMinor))
+	assert.False(t, Exceeds(LevelPatch, LevelMajor))
+	assert.False(t, Exceeds(LevelUnknown, LevelPatch))
+	assert.False(t, Exceeds(LevelMajor, LevelUnknown))
+}
+
+func TestParseUpdateType(t *testing.T) {
+	message := `Bump actions/checkout from 3.6.0 to 4.1.1
+
+Bumps [actions/checkout](https://github.com/actions/checkout) from 3.6.0 to 4.1.1.
+- [Release notes](https://github.com/actions/checkout/releases)
+- [Changelog](https://github.com/actions/checkout/blob/main/CHANGELOG.md)
+- [Commits](https://github.com/actions/checkout/compare/v3.6.0...v4.1.1)
+
+---
+updated-dependencies:
+- dependency-name: actions/checkout
+  dependency-type: direct:production
+  update-type: version-update:semver-major
+...
+
+Signed-off-by: dependabot[bot] <support@github.com>`
+
+	assert.Equal(t, LevelMajor, ParseUpdateType(message))
+	assert.Equal(t, LevelUnknown, ParseUpdateType("Bump actions/checkout from 3.6.0 to 4.1.1"))
+}
@@ -0,0 +1,32 @@
+package metadata
+
+// Parser extracts the update information out of a pull request of a bot.
+type Parser func(number int, url, title, body, branch string) Update
+
+// Names of the parsers.
+const (
+	ParserDependabot = "dependabot"
+	ParserRenovate   = "renovate"
+)
+
+var parsers = map[string]Parser{
+	ParserDependabot: ParseDependabot,
+	ParserRenovate:   ParseRenovate,
+}
+
+// LookupParser returns the parser with the name.
+func LookupParser(name string) (Parser, bool) {
+	parser, ok := parsers[name]
+
+	return parser, ok
+}
+
+// DetectParser returns the name of the parser for a pull request with the branch: renovate for branches of
+// Renovate, dependabot otherwise.
+func DetectParser(branch string) string {
+	if IsRenovate(branch) {
+		return ParserRenovate
+	}
+
+	return ParserDependabot
+}
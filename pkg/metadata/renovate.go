@@ -0,0 +1,161 @@
+package metadata
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	// | [github.com/stretchr/testify](https://togithub.com/stretchr/testify) | require | minor | `v1.8.4` -> `v1.9.0` |
+	renovateChangeRegexp = regexp.MustCompile("`([^`]+)`\\s*(?:->|→)\\s*`([^`]+)`")
+	renovateLinkRegexp   = regexp.MustCompile(`^\[([^\]]+)\]\(.*\)$`)
+	// chore(deps): update module github.com/stretchr/testify to v1.9.0
+	// chore(deps): update actions/checkout action to v4
+	renovateModuleTitleRegexp = regexp.MustCompile(`[Uu]pdate module (\S+)`)
+	renovateActionTitleRegexp = regexp.MustCompile(`[Uu]pdate (\S+) action`)
+	renovateToTitleRegexp     = regexp.MustCompile(` to (\S+)$`)
+)
+
+// renovateTypes maps the dependency types of Renovate's update table to the ecosystem names of Dependabot,
+// which are used for filtering and by the updaters.
+var renovateTypes = map[string]string{
+	"require":              "go_modules",
+	"indirect":             "go_modules",
+	"replace":              "go_modules",
+	"toolchain":            "go_modules",
+	"action":               "github_actions",
+	"dependencies":         "npm_and_yarn",
+	"devDependencies":      "npm_and_yarn",
+	"peerDependencies":     "npm_and_yarn",
+	"optionalDependencies": "npm_and_yarn",
+	"final":                "docker",
+	"stage":                "docker",
+}
+
+// IsRenovate returns whether the branch was created by Renovate, for example renovate/github.com-stretchr-testify-1.x.
+func IsRenovate(branch string) bool {
+	return strings.HasPrefix(branch, "renovate/")
+}
+
+// ParseRenovate extracts the update information out of a Renovate pull request. The details are taken from the
+// first row of the update table in the body:
+//
+//	| Package | Type | Update | Change |
+//	|---|---|---|---|
+//	| [github.com/stretchr/testify](https://togithub.com/stretchr/testify) | require | minor | `v1.8.4` -> `v1.9.0` |
+//
+// If the table is missing, the dependency and the new version are taken from the title. The directory is only
+// known if the table has a `Package file` column, otherwise it's left empty.
+func ParseRenovate(number int, url, title, body, branch string) Update {
+	update := Update{
+		Number: number,
+		URL:    url,
+	}
+
+	if matches := renovateModuleTitleRegexp.FindStringSubmatch(title); matches != nil {
+		update.Dependency, update.Ecosystem = matches[1], "go_modules"
+	} else if matches := renovateActionTitleRegexp.FindStringSubmatch(title); matches != nil {
+		update.Dependency, update.Ecosystem = matches[1], "github_actions"
+	}
+
+	if matches := renovateToTitleRegexp.FindStringSubmatch(strings.TrimSpace(title)); matches != nil {
+		update.To = matches[1]
+	}
+
+	row := renovateTable(body)
+	if row == nil {
+		return update
+	}
+
+	if dependency := strings.Trim(row["package"], "`"); dependency != "" {
+		update.Dependency = dependency
+	}
+
+	if ecosystem, ok := renovateTypes[row["type"]]; ok {
+		update.Ecosystem = ecosystem
+	}
+
+	switch level := row["update"]; level {
+	case LevelMajor, LevelMinor, LevelPatch:
+		update.UpdateType = level
+	}
+
+	if matches := renovateChangeRegexp.FindStringSubmatch(row["change"]); matches != nil {
+		update.From, update.To = matches[1], matches[2]
+	}
+
+	if file := strings.Trim(row["package file"], "`"); file != "" {
+		update.Directory = renovateDirectory(file, update.Ecosystem)
+	}
+
+	return update
+}
+
+// renovateDirectory returns the directory of a package file, like Dependabot would name it. The workflows of
+// GitHub Actions belong to the root of the repository.
+func renovateDirectory(file, ecosystem string) string {
+	dir := "/" + strings.TrimPrefix(path.Dir(path.Clean("/"+file)), "/")
+	if ecosystem == "github_actions" && dir == "/.github/workflows" {
+		return "/"
+	}
+
+	return dir
+}
+
+// renovateTable returns the cells of the first row of the update table by lower case column name, or nil if
+// there is no table.
+func renovateTable(body string) map[string]string {
+	var header []string
+
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "|") {
+			header = nil
+
+			continue
+		}
+
+		cells := tableCells(line)
+
+		switch {
+		case header == nil:
+			header = cells
+		case strings.HasPrefix(cells[0], "-") || strings.HasPrefix(cells[0], ":-"):
+			// the separator below the header
+		default:
+			row := make(map[string]string, len(header))
+			for i, name := range header {
+				if i < len(cells) {
+					row[strings.ToLower(name)] = cells[i]
+				}
+			}
+
+			if _, ok := row["package"]; ok {
+				return row
+			}
+
+			header = nil
+		}
+	}
+
+	return nil
+}
+
+// tableCells splits a markdown table row into its trimmed cells. Links are replaced with their text, unless
+// it contains code.
+func tableCells(line string) []string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	cells := strings.Split(line, "|")
+
+	for i, cell := range cells {
+		cell = strings.TrimSpace(cell)
+		if matches := renovateLinkRegexp.FindStringSubmatch(cell); matches != nil && !strings.Contains(matches[1], "`") {
+			cell = matches[1]
+		}
+
+		cells[i] = cell
+	}
+
+	return cells
+}
@@ -0,0 +1,116 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRenovate(t *testing.T) {
+	body := "[![Mend Renovate](https://app.renovatebot.com/images/banner.svg)](https://renovatebot.com)\n\n" +
+		"This PR contains the following updates:\n\n" +
+		"| Package | Type | Update | Change | Package file |\n" +
+		"|---|---|---|---|---|\n" +
+		"| [github.com/stretchr/testify](https://togithub.com/stretchr/testify) | require | minor | " +
+		"`v1.8.4` -> `v1.9.0` | `hack/tools/go.mod` |\n\n" +
+		"---\n\n### Release Notes\n"
+
+	update := ParseRenovate(
+		7,
+		"https://github.com/test/test/pull/7",
+		"fix(deps): update module github.com/stretchr/testify to v1.9.0",
+		body,
+		"renovate/github.com-stretchr-testify-1.x",
+	)
+	assert.Equal(t, Update{
+		Number:     7,
+		URL:        "https://github.com/test/test/pull/7",
+		Dependency: "github.com/stretchr/testify",
+		Ecosystem:  "go_modules",
+		Directory:  "/hack/tools",
+		From:       "v1.8.4",
+		To:         "v1.9.0",
+		UpdateType: LevelMinor,
+	}, update)
+}
+
+func TestParseRenovateLinkedChange(t *testing.T) {
+	body := "| Package | Change | Age | Confidence |\n" +
+		"|---|---|---|---|\n" +
+		"| [actions/checkout](https://togithub.com/actions/checkout) | " +
+		"[`v3` -> `v4`](https://renovatebot.com/diffs/npm/actions%2fcheckout/v3/v4) | " +
+		"[![age](https://developer.mend.io/api/mc/badges/age/github-tags/actions%2fcheckout/v4?slim=true)]" +
+		"(https://docs.renovatebot.com/merge-confidence/) | |\n"
+
+	update := ParseRenovate(
+		8, "", "chore(deps): update actions/checkout action to v4", body, "renovate/actions-checkout-4.x",
+	)
+	assert.Equal(t, Update{
+		Number:     8,
+		Dependency: "actions/checkout",
+		Ecosystem:  "github_actions",
+		From:       "v3",
+		To:         "v4",
+	}, update)
+	assert.Equal(t, LevelMajor, update.Level())
+}
+
+func TestParseRenovateTitleOnly(t *testing.T) {
+	update := ParseRenovate(
+		9, "", "chore(deps): update module golang.org/x/sys to v0.15.0", "", "renovate/golang.org-x-sys-0.x",
+	)
+	assert.Equal(t, Update{
+		Number:     9,
+		Dependency: "golang.org/x/sys",
+		Ecosystem:  "go_modules",
+		To:         "v0.15.0",
+	}, update)
+}
+
+func TestParseRenovateDirectory(t *testing.T) {
+	tests := []struct {
+		file      string
+		kind      string
+		directory string
+	}{
+		{file: "go.mod", kind: "require", directory: "/"},
+		{file: "hack/tools/go.mod", kind: "require", directory: "/hack/tools"},
+		{file: ".github/workflows/ci.yml", kind: "action", directory: "/"},
+		{file: ".github/actions/build/action.yml", kind: "action", directory: "/.github/actions/build"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			body := "| Package | Type | Update | Change | Package file |\n" +
+				"|---|---|---|---|---|\n" +
+				"| dependency | " + tt.kind + " | minor | `v1.0.0` -> `v1.1.0` | `" + tt.file + "` |\n"
+
+			update := ParseRenovate(1, "", "chore(deps): update dependency to v1.1.0", body, "renovate/dependency-1.x")
+			assert.Equal(t, tt.directory, update.Directory)
+		})
+	}
+}
+
+func TestIsRenovate(t *testing.T) {
+	assert.True(t, IsRenovate("renovate/actions-checkout-4.x"))
+	assert.False(t, IsRenovate("dependabot/github_actions/actions/checkout-4"))
+}
+
+func TestAsDependabot(t *testing.T) {
+	title, body, branch := Update{
+		Dependency: "actions/checkout",
+		Ecosystem:  "github_actions",
+		From:       "v3",
+		To:         "v4",
+	}.AsDependabot()
+	assert.Equal(t, "Bump actions/checkout from 3 to 4", title)
+	assert.Equal(t, "Bumps [actions/checkout](actions/checkout) from 3 to 4.", body)
+	assert.Equal(t, "dependabot/github_actions/actions/checkout-4", branch)
+
+	// the result is understood by the Dependabot parser
+	update := ParseDependabot(1, "", title+" in /tools", body, branch)
+	assert.Equal(t, "github_actions", update.Ecosystem)
+	assert.Equal(t, "actions/checkout", update.Dependency)
+	assert.Equal(t, "/tools", update.Directory)
+	assert.Equal(t, "4", update.To)
+}
@@ -0,0 +1,119 @@
+package metadata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Semantic version levels of an update.
+const (
+	LevelPatch   = "patch"
+	LevelMinor   = "minor"
+	LevelMajor   = "major"
+	LevelUnknown = ""
+)
+
+// Update describes a single dependency update proposed by a pull request of a bot.
+type Update struct {
+	// Number is the number of the pull request.
+	Number int
+	// URL is the HTML URL of the pull request.
+	URL string
+	// Dependency is the name of the updated dependency.
+	Dependency string
+	// Ecosystem is the package ecosystem as it appears in the branch name, for example go_modules.
+	Ecosystem string
+	// Directory is the location of the manifest in the repository, for example `/`. Empty if it is unknown.
+	Directory string
+	// From is the version the dependency is updated from.
+	From string
+	// To is the version the dependency is updated to.
+	To string
+	// UpdateType is the level of the update as reported by the bot, for example Dependabot's update-type.
+	// If set, it takes precedence over the level computed from the versions.
+	UpdateType string
+}
+
+// Level returns the semantic version level of the update. If it can't be determined, LevelUnknown is returned.
+func (u Update) Level() string {
+	if u.UpdateType != "" {
+		return u.UpdateType
+	}
+
+	return Level(u.From, u.To)
+}
+
+// ValidateLevel checks that level is one an update can be limited to. An empty level means no limit.
+func ValidateLevel(level string) error {
+	switch level {
+	case LevelUnknown, LevelPatch, LevelMinor, LevelMajor:
+		return nil
+	default:
+		return fmt.Errorf("must be patch, minor or major, got %s", level)
+	}
+}
+
+// Exceeds returns whether level is higher than limit. Unknown levels never exceed anything.
+func Exceeds(level, limit string) bool {
+	rank := map[string]int{LevelPatch: 1, LevelMinor: 2, LevelMajor: 3}
+	if rank[level] == 0 || rank[limit] == 0 {
+		return false
+	}
+
+	return rank[level] > rank[limit]
+}
+
+// Level compares two versions and returns which semantic version component changed between them.
+// If the versions can't be parsed, for example because they are commit SHAs, LevelUnknown is returned.
+func Level(from, to string) string {
+	fromParts, ok := versionParts(from)
+	if !ok {
+		return LevelUnknown
+	}
+
+	toParts, ok := versionParts(to)
+	if !ok {
+		return LevelUnknown
+	}
+
+	levels := []string{LevelMajor, LevelMinor, LevelPatch}
+	for i, level := range levels {
+		if fromParts[i] != toParts[i] {
+			return level
+		}
+	}
+
+	// only the pre-release or build part changed
+	return LevelPatch
+}
+
+// versionParts returns the major, minor and patch components of a version. Missing components are zero.
+func versionParts(version string) ([3]int, bool) {
+	var parts [3]int
+
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i > -1 {
+		version = version[:i]
+	}
+
+	if version == "" {
+		return parts, false
+	}
+
+	split := strings.Split(version, ".")
+	if len(split) > len(parts) {
+		return parts, false
+	}
+
+	for i, s := range split {
+		value, err := strconv.Atoi(s)
+		if err != nil {
+			return parts, false
+		}
+
+		parts[i] = value
+	}
+
+	return parts, true
+}
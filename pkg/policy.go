@@ -0,0 +1,75 @@
+package pkg
+
+import (
+	"context"
+
+	"github.com/google/go-github/v43/github"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/metadata"
+)
+
+// maxLevel returns the highest update level which is bundled. An empty level means no limit.
+func (n *Bundler) maxLevel() string {
+	if n.ExcludeMajor && (n.MaxUpdateLevel == "" || n.MaxUpdateLevel == metadata.LevelMajor) {
+		return metadata.LevelMinor
+	}
+
+	return n.MaxUpdateLevel
+}
+
+// applyLevelPolicy splits the candidates into the ones which are bundled and the updates which exceed the
+// maximum update level. Updates of which the level can't be determined are always bundled.
+func (n *Bundler) applyLevelPolicy(candidates []candidate) ([]candidate, []metadata.Update) {
+	limit := n.maxLevel()
+	if limit == "" {
+		return candidates, nil
+	}
+
+	var (
+		result   []candidate
+		excluded []metadata.Update
+	)
+
+	for _, c := range candidates {
+		if level := n.updateLevel(&c); metadata.Exceeds(level, limit) {
+			n.Logger.Log("skipping PR #%d: %s update exceeds the maximum update level %s\n", c.update.Number, level, limit)
+			excluded = append(excluded, c.update)
+
+			continue
+		}
+
+		result = append(result, c)
+	}
+
+	return result, excluded
+}
+
+// updateLevel returns the level of the update of the candidate. Dependabot only reports it in the update-type
+// trailer of its commit message, so on GitHub, it's read from the head commit of the pull request if the
+// description doesn't contain it. Otherwise, the level is computed from the versions.
+func (n *Bundler) updateLevel(c *candidate) string {
+	if c.update.UpdateType == "" && c.parser == metadata.ParserDependabot && n.onGitHub() {
+		c.update.UpdateType = n.commitUpdateType(c.update.Number)
+	}
+
+	return c.update.Level()
+}
+
+// commitUpdateType returns the update-type of the head commit of the pull request, or LevelUnknown if it can't
+// be determined.
+func (n *Bundler) commitUpdateType(number int) string {
+	commits, _, err := n.Pulls.ListCommits(context.Background(), n.Owner, n.Repo, number, &github.ListOptions{
+		PerPage: defaultNumberOfItemsPerPage,
+	})
+	if err != nil {
+		n.Logger.Debug("failed to list commits of PR #%d, using the versions for its level: %s\n", number, err)
+
+		return metadata.LevelUnknown
+	}
+
+	if len(commits) == 0 {
+		return metadata.LevelUnknown
+	}
+
+	return metadata.ParseUpdateType(commits[len(commits)-1].GetCommit().GetMessage())
+}
@@ -8,7 +8,7 @@ import (
 )
 
 type FakeUpdater struct {
-	UpdateStub        func(string, string, string) ([]string, error)
+	UpdateStub        func(string, string, string) ([]providers.FileChange, error)
 	updateMutex       sync.RWMutex
 	updateArgsForCall []struct {
 		arg1 string
@@ -16,18 +16,18 @@ type FakeUpdater struct {
 		arg3 string
 	}
 	updateReturns struct {
-		result1 []string
+		result1 []providers.FileChange
 		result2 error
 	}
 	updateReturnsOnCall map[int]struct {
-		result1 []string
+		result1 []providers.FileChange
 		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeUpdater) Update(arg1 string, arg2 string, arg3 string) ([]string, error) {
+func (fake *FakeUpdater) Update(arg1 string, arg2 string, arg3 string) ([]providers.FileChange, error) {
 	fake.updateMutex.Lock()
 	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
 	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
@@ -54,7 +54,7 @@ func (fake *FakeUpdater) UpdateCallCount() int {
 	return len(fake.updateArgsForCall)
 }
 
-func (fake *FakeUpdater) UpdateCalls(stub func(string, string, string) ([]string, error)) {
+func (fake *FakeUpdater) UpdateCalls(stub func(string, string, string) ([]providers.FileChange, error)) {
 	fake.updateMutex.Lock()
 	defer fake.updateMutex.Unlock()
 	fake.UpdateStub = stub
@@ -67,28 +67,28 @@ func (fake *FakeUpdater) UpdateArgsForCall(i int) (string, string, string) {
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeUpdater) UpdateReturns(result1 []string, result2 error) {
+func (fake *FakeUpdater) UpdateReturns(result1 []providers.FileChange, result2 error) {
 	fake.updateMutex.Lock()
 	defer fake.updateMutex.Unlock()
 	fake.UpdateStub = nil
 	fake.updateReturns = struct {
-		result1 []string
+		result1 []providers.FileChange
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeUpdater) UpdateReturnsOnCall(i int, result1 []string, result2 error) {
+func (fake *FakeUpdater) UpdateReturnsOnCall(i int, result1 []providers.FileChange, result2 error) {
 	fake.updateMutex.Lock()
 	defer fake.updateMutex.Unlock()
 	fake.UpdateStub = nil
 	if fake.updateReturnsOnCall == nil {
 		fake.updateReturnsOnCall = make(map[int]struct {
-			result1 []string
+			result1 []providers.FileChange
 			result2 error
 		})
 	}
 	fake.updateReturnsOnCall[i] = struct {
-		result1 []string
+		result1 []providers.FileChange
 		result2 error
 	}{result1, result2}
 }
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/Skarlso/dependabot-bundler/pkg/api"
+	"github.com/Skarlso/dependabot-bundler/pkg/providers"
 )
 
 const extractFromAndToRegexp = `Bumps \[(.*)\].*from (.*) to ([a-z|A-Z|0-9\.]+)`
@@ -29,7 +30,7 @@ func NewGithubActionUpdater(git api.Git) *GithubActionUpdater {
 }
 
 // Update updates a dependency using go get in the current working directory.
-func (g *GithubActionUpdater) Update(body, branch, title string) ([]string, error) {
+func (g *GithubActionUpdater) Update(body, branch, title string) ([]providers.FileChange, error) {
 	if !strings.Contains(branch, "github_actions") {
 		return nil, fmt.Errorf("github_actions was not in the branch name: %s", branch)
 	}
@@ -46,7 +47,7 @@ func (g *GithubActionUpdater) Update(body, branch, title string) ([]string, erro
 		return nil, fmt.Errorf("failed to get current working folder: %w", err)
 	}
 
-	var modifiedFiles []string
+	var modifiedFiles []providers.FileChange
 
 	err = filepath.Walk(filepath.Join(cwd, ".github", "workflows"),
 		func(path string, info os.FileInfo, err error) error {
@@ -84,7 +85,7 @@ func (g *GithubActionUpdater) Update(body, branch, title string) ([]string, erro
 				// This is the full path. Trim the current working directory from it
 				path = strings.TrimPrefix(path, cwd)
 				path = strings.TrimPrefix(path, "/")
-				modifiedFiles = append(modifiedFiles, path)
+				modifiedFiles = append(modifiedFiles, providers.FileChange{Path: path, Kind: providers.Modified})
 			}
 
 			return nil
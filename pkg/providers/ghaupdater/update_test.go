@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/Skarlso/dependabot-bundler/pkg/api/fakes"
+	"github.com/Skarlso/dependabot-bundler/pkg/providers"
 )
 
 func TestNameUpdate(t *testing.T) {
@@ -24,7 +25,7 @@ func TestNameUpdate(t *testing.T) {
 	gau := NewGithubActionUpdater(git)
 	files, err := gau.Update("Bumps [actions/checkout](https://github.com/actions/checkout) from 2 to 3", "github_actions", "")
 	assert.NoError(t, err)
-	assert.Equal(t, []string{".github/workflows/test.yaml"}, files)
+	assert.Equal(t, []providers.FileChange{{Path: ".github/workflows/test.yaml", Kind: providers.Modified}}, files)
 	newContent, err := os.ReadFile(testFile)
 	assert.NoError(t, err)
 	assert.Contains(t, string(newContent), "uses: actions/checkout@v3")
@@ -51,7 +52,7 @@ func TestWithGitSHAPin(t *testing.T) {
 	gau := NewGithubActionUpdater(git)
 	files, err := gau.Update("Bumps [docker/metadata-action](https://github.com/docker/metadata-action) from 3.3.0 to 4.0.1.", "github_actions", "")
 	assert.NoError(t, err)
-	assert.Equal(t, []string{".github/workflows/test.yaml"}, files)
+	assert.Equal(t, []providers.FileChange{{Path: ".github/workflows/test.yaml", Kind: providers.Modified}}, files)
 	newContent, err := os.ReadFile(testFile)
 	assert.NoError(t, err)
 	assert.Contains(t, string(newContent), "uses: docker/metadata-action@69f6fc9d46f2f8bf0d5491e4aabe0bb8c6a4678a")
@@ -78,7 +79,7 @@ func TestWithGitSHAWithComment(t *testing.T) {
 	gau := NewGithubActionUpdater(git)
 	files, err := gau.Update("Bumps [lycheeverse/lychee-action](https://github.com/lycheeverse/lychee-action) from c0d1093b783f7ad0c445884b01da0215b2da29ee to 1.5.0 blabla bla bla.", "github_actions", "")
 	assert.NoError(t, err)
-	assert.Equal(t, []string{".github/workflows/test.yaml"}, files)
+	assert.Equal(t, []providers.FileChange{{Path: ".github/workflows/test.yaml", Kind: providers.Modified}}, files)
 	newContent, err := os.ReadFile(testFile)
 	assert.NoError(t, err)
 	assert.Contains(t, string(newContent), "uses: lycheeverse/lychee-action@76ab977fedbeaeb32029313724a2e56a8a393548")
@@ -1,7 +1,9 @@
 package mupdater
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -31,7 +33,7 @@ func NewGoUpdater(log logger.Logger, next providers.Updater, runner providers.Ru
 }
 
 // Update updates a dependency using go get in the current working directory.
-func (g *GoUpdater) Update(body, branch, title string) ([]string, error) {
+func (g *GoUpdater) Update(body, branch, title string) ([]providers.FileChange, error) {
 	if !strings.Contains(branch, "go_modules") {
 		if g.Next == nil {
 			return nil, fmt.Errorf("no Next updater defined")
@@ -56,6 +58,12 @@ func (g *GoUpdater) Update(body, branch, title string) ([]string, error) {
 
 	g.Logger.Log("updating dependency for %s at location %s\n", module, workdir)
 
+	// go.sum is created by the update if the module had no dependencies before.
+	sumKind := providers.Modified
+	if _, err := os.Stat(filepath.Join(workdir, "go.sum")); errors.Is(err, os.ErrNotExist) {
+		sumKind = providers.Added
+	}
+
 	if output, err := g.Runner.Run("go", workdir, "get", "-u", module); err != nil {
 		g.Logger.Debug("update failed, output from command: %s; error: %s", string(output), err)
 
@@ -68,7 +76,10 @@ func (g *GoUpdater) Update(body, branch, title string) ([]string, error) {
 		return nil, fmt.Errorf("failed to run go mod tidy: %w", err)
 	}
 
-	return []string{filepath.Join(workdir, "go.mod"), filepath.Join(workdir, "go.sum")}, nil
+	return []providers.FileChange{
+		{Path: filepath.Join(workdir, "go.mod"), Kind: providers.Modified},
+		{Path: filepath.Join(workdir, "go.sum"), Kind: sumKind},
+	}, nil
 }
 
 func (g *GoUpdater) extractModuleName(description string) string {
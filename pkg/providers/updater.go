@@ -1,9 +1,30 @@
 package providers
 
-// Updater updates a specific module. Returns a list of modified files.
+// ChangeKind defines how a file was changed by an update.
+type ChangeKind int
+
+const (
+	// Modified means the content of an existing file changed.
+	Modified ChangeKind = iota
+	// Added means the file didn't exist before the update.
+	Added
+	// Deleted means the file was removed by the update.
+	Deleted
+	// ModeChanged means only the mode of the file changed, for example it became executable.
+	ModeChanged
+)
+
+// FileChange is a file which was changed by an update.
+type FileChange struct {
+	// Path is the location of the file relative to the root of the repository.
+	Path string
+	Kind ChangeKind
+}
+
+// Updater updates a specific module. Returns a list of changed files.
 //
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fakes/fake_updater.go . Updater
 type Updater interface {
-	Update(module, branch, title string) ([]string, error)
+	Update(module, branch, title string) ([]FileChange, error)
 }
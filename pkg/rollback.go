@@ -0,0 +1,70 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v43/github"
+)
+
+// rollbackStep undoes a single side effect of a run.
+type rollbackStep struct {
+	description string
+	undo        func() error
+}
+
+// rollback keeps track of the side effects of a run, such as created branches and PRs, so they can be
+// undone if the run fails halfway.
+type rollback struct {
+	steps []rollbackStep
+}
+
+// add records a side effect together with the function which undoes it.
+func (r *rollback) add(description string, undo func() error) {
+	r.steps = append(r.steps, rollbackStep{description: description, undo: undo})
+}
+
+// runRollback undoes the recorded side effects in reverse order. Failures are logged and skipped.
+func (n *Bundler) runRollback() {
+	for i := len(n.undo.steps) - 1; i >= 0; i-- {
+		step := n.undo.steps[i]
+
+		n.Logger.Log("rolling back: %s\n", step.description)
+
+		if err := step.undo(); err != nil {
+			n.Logger.Log("failed to roll back, skipping: %s\n", err)
+		}
+	}
+}
+
+// deleteBranch deletes the branch from the repository.
+func (n *Bundler) deleteBranch(branch string) error {
+	if _, err := n.Git.DeleteRef(context.Background(), n.Owner, n.Repo, "refs/heads/"+branch); err != nil {
+		return fmt.Errorf("failed to delete ref: %w", err)
+	}
+
+	return nil
+}
+
+// resetBranch forces the branch back to the given commit.
+func (n *Bundler) resetBranch(branch, sha string) error {
+	if _, _, err := n.Git.UpdateRef(context.Background(), n.Owner, n.Repo, &github.Reference{
+		Ref:    github.String("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: github.String(sha)},
+	}, true); err != nil {
+		return fmt.Errorf("failed to update ref: %w", err)
+	}
+
+	return nil
+}
+
+// closePR closes the pull request.
+func (n *Bundler) closePR(number int) error {
+	if _, _, err := n.Pulls.Edit(context.Background(), n.Owner, n.Repo, number, &github.PullRequest{
+		State: github.String("closed"),
+	}); err != nil {
+		return fmt.Errorf("failed to close pull request: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v43/github"
+)
+
+// commentMarker is a hidden marker placed into every comment the bundler creates on a bundled PR.
+// It is used to update the comment on later runs instead of posting a new one.
+const commentMarker = "<!-- dependabot-bundler-comment -->"
+
+// Modes which define when the PRs included in a bundle are closed.
+const (
+	// CloseNever leaves the bundled PRs open.
+	CloseNever = ""
+	// CloseOnOpen closes the bundled PRs as soon as the bundle PR is opened or updated.
+	CloseOnOpen = "on-open"
+	// CloseOnMerge closes the bundled PRs once the bundle PR has been merged. This is checked
+	// at the beginning of every run.
+	CloseOnMerge = "on-merge"
+)
+
+// includedRegexp matches the hidden marker which lists the numbers of the bundled PRs.
+var includedRegexp = regexp.MustCompile(`<!-- dependabot-bundler-prs: ([0-9,]*) -->`)
+
+// includedMarker returns a hidden marker which records the bundled PR numbers in the body of the bundle.
+func includedMarker(numbers []int) string {
+	values := make([]string, 0, len(numbers))
+	for _, number := range numbers {
+		values = append(values, strconv.Itoa(number))
+	}
+
+	return fmt.Sprintf("<!-- dependabot-bundler-prs: %s -->", strings.Join(values, ","))
+}
+
+// parseIncluded returns the PR numbers recorded in the body of a bundle.
+func parseIncluded(body string) []int {
+	matches := includedRegexp.FindStringSubmatch(body)
+
+	const numbersIndex = 2
+	if len(matches) < numbersIndex || matches[1] == "" {
+		return nil
+	}
+
+	var result []int
+
+	for _, value := range strings.Split(matches[1], ",") {
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+
+		result = append(result, number)
+	}
+
+	return result
+}
+
+// closeMergedBundles looks for merged bundle PRs and closes the PRs they contained which are still open.
+func (n *Bundler) closeMergedBundles() error {
+	prs, _, err := n.Pulls.List(context.Background(), n.Owner, n.Repo, &github.PullRequestListOptions{
+		State:     "closed",
+		Base:      n.TargetBranch,
+		Sort:      "updated",
+		Direction: "desc",
+		ListOptions: github.ListOptions{
+			PerPage: defaultNumberOfItemsPerPage,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list closed pull requests: %w", err)
+	}
+
+	for _, bundle := range prs {
+		if bundle.MergedAt == nil || !n.isBundle(bundle, n.branchPrefixes()...) {
+			continue
+		}
+
+		var open []int
+
+		for _, number := range parseIncluded(bundle.GetBody()) {
+			pr, _, err := n.Pulls.Get(context.Background(), n.Owner, n.Repo, number)
+			if err != nil {
+				n.Logger.Debug("failed to get pull request for number %d with error %s, skipping \n", number, err)
+
+				continue
+			}
+
+			if pr.GetState() == "open" {
+				open = append(open, number)
+			}
+		}
+
+		n.closeSuperseded(bundle.GetNumber(), open, fmt.Sprintf("Included in #%d which has been merged.", bundle.GetNumber()))
+	}
+
+	return nil
+}
+
+// closeSuperseded comments on each given PR with the message and closes it. Failures are logged and skipped.
+func (n *Bundler) closeSuperseded(bundle int, numbers []int, message string) {
+	for _, number := range numbers {
+		n.Logger.Log("closing PR #%d in favor of #%d\n", number, bundle)
+
+		if err := n.upsertComment(number, message); err != nil {
+			n.Logger.Log("failed to comment on PR #%d, skipping: %s\n", number, err)
+
+			continue
+		}
+
+		if err := n.closePR(number); err != nil {
+			n.Logger.Log("failed to close PR #%d: %s\n", number, err)
+		}
+	}
+}
+
+// commentOnIncluded leaves a comment with a link to the bundle on each bundled PR. Failures are logged and skipped.
+func (n *Bundler) commentOnIncluded(bundle int, numbers []int) {
+	for _, number := range numbers {
+		if err := n.upsertComment(number, fmt.Sprintf("Included in #%d.", bundle)); err != nil {
+			n.Logger.Log("failed to comment on PR #%d, skipping: %s\n", number, err)
+		}
+	}
+}
+
+// upsertComment edits the comment of the bundler on the given PR or creates one if there is none yet.
+func (n *Bundler) upsertComment(number int, message string) error {
+	body := message + "\n\n" + commentMarker
+
+	comments, _, err := n.Issues.ListComments(context.Background(), n.Owner, n.Repo, number, &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{
+			PerPage: defaultNumberOfItemsPerPage,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	for _, comment := range comments {
+		if !strings.Contains(comment.GetBody(), commentMarker) {
+			continue
+		}
+
+		if comment.GetBody() == body {
+			return nil
+		}
+
+		if _, _, err := n.Issues.EditComment(context.Background(), n.Owner, n.Repo, comment.GetID(), &github.IssueComment{
+			Body: &body,
+		}); err != nil {
+			return fmt.Errorf("failed to edit comment: %w", err)
+		}
+
+		return nil
+	}
+
+	if _, _, err := n.Issues.CreateComment(context.Background(), n.Owner, n.Repo, number, &github.IssueComment{
+		Body: &body,
+	}); err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return nil
+}
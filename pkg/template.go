@@ -0,0 +1,96 @@
+package pkg
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/metadata"
+)
+
+const defaultCommitMessage = "Bundling updated dependencies."
+
+// TemplateData is the data the PR title, PR body and commit message templates are rendered with.
+type TemplateData struct {
+	// Updates contains the bundled updates. See metadata.Update for the available fields.
+	Updates []metadata.Update
+	// Count is the number of bundled updates.
+	Count int
+	// Excluded contains the updates which were left out because they exceed the maximum update level.
+	Excluded []metadata.Update
+	// Date is the time at which the bundle is created in UTC.
+	Date time.Time
+	// TargetBranch is the branch the PR is opened against.
+	TargetBranch string
+	// Group is the name of the bundled group, empty without groups.
+	Group string
+	// Reference is the prefix which links the number of a PR, `#` on GitHub and `!` for the MRs of GitLab.
+	Reference string
+}
+
+// messages contains the rendered texts of a bundle.
+type messages struct {
+	title  string
+	body   string
+	commit string
+}
+
+// renderMessages renders the PR title, the PR body and the commit message. If no template is configured
+// for one of them, the default is used. The hidden markers of the bundler are always added to the body.
+func (n *Bundler) renderMessages(updates, excluded []metadata.Update) (messages, error) {
+	data := TemplateData{
+		Updates:      updates,
+		Count:        len(updates),
+		Excluded:     excluded,
+		Date:         time.Now().UTC(),
+		TargetBranch: n.TargetBranch,
+		Group:        n.group,
+		Reference:    n.forge().ReferencePrefix(),
+	}
+
+	title, err := render("pr-title", n.PRTitleTemplate, n.PRTitle, data)
+	if err != nil {
+		return messages{}, err
+	}
+
+	body, err := render("pr-body", n.PRBodyTemplate, description(updates, excluded, data.Reference), data)
+	if err != nil {
+		return messages{}, err
+	}
+
+	commit, err := render("commit-message", n.CommitMessageTemplate, defaultCommitMessage, data)
+	if err != nil {
+		return messages{}, err
+	}
+
+	markers := bundleMarker + "\n" + includedMarker(numbers(updates))
+	if n.group != "" {
+		markers += "\n" + groupMarker(n.group)
+	}
+
+	return messages{
+		title:  strings.TrimSpace(title),
+		body:   body + "\n" + markers,
+		commit: commit,
+	}, nil
+}
+
+// render executes the template text with the data. If text is empty, fallback is returned.
+func render(name, text, fallback string, data TemplateData) (string, error) {
+	if text == "" {
+		return fallback, nil
+	}
+
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to execute %s template: %w", name, err)
+	}
+
+	return b.String(), nil
+}
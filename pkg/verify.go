@@ -0,0 +1,127 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v43/github"
+
+	"github.com/Skarlso/dependabot-bundler/pkg/forge"
+)
+
+// knownBotIDs are the IDs of the bot accounts of well known GitHub Apps on github.com. The API doesn't tell which
+// app opened a pull request, but every app acts through a bot account with a fixed ID.
+var knownBotIDs = map[string]int64{
+	"dependabot[bot]": 49699333,
+	"renovate[bot]":   29139614,
+}
+
+// botLogin returns the login of the account the name of a bot refers to. On GitHub, pull requests are listed
+// with the name of the app, such as app/dependabot, while they are opened by its bot account dependabot[bot].
+func botLogin(name string) string {
+	if app, ok := strings.CutPrefix(name, "app/"); ok {
+		return app + "[bot]"
+	}
+
+	return name
+}
+
+// verify returns why the change request can't be trusted, or an empty string if it can. With VerifyBots, it
+// has to be opened by one of the bots from a branch of the repository itself. On GitHub, the author also has
+// to be a bot account with the ID of the bot, if it's known. With RequireVerifiedCommits, every commit has to
+// carry a verified signature.
+func (n *Bundler) verify(request *forge.ChangeRequest) (string, error) {
+	if !n.VerifyBots && !n.RequireVerifiedCommits {
+		return "", nil
+	}
+
+	if n.VerifyBots {
+		if reason := n.verifyAuthor(request.Author); reason != "" {
+			return reason, nil
+		}
+
+		if request.HeadRepository != request.BaseRepository {
+			return fmt.Sprintf("head repository %s is not the base repository %s",
+				request.HeadRepository, request.BaseRepository), nil
+		}
+	}
+
+	if n.RequireVerifiedCommits {
+		return n.verifyCommits(request.Number)
+	}
+
+	return "", nil
+}
+
+// verifyAuthor returns why the author isn't one of the bots, or an empty string if it is.
+func (n *Bundler) verifyAuthor(author forge.Author) string {
+	bots := n.bots()
+
+	i := 0
+	for i < len(bots) && !strings.EqualFold(botLogin(bots[i].Name), author.Login) {
+		i++
+	}
+
+	if i == len(bots) {
+		return fmt.Sprintf("author %s is not one of the bots", author.Login)
+	}
+
+	// other forges don't report the type of the account
+	if !n.onGitHub() {
+		return ""
+	}
+
+	if !author.Bot {
+		return fmt.Sprintf("author %s is not a bot account", author.Login)
+	}
+
+	id := bots[i].ID
+	if id == 0 && !n.Enterprise {
+		id = knownBotIDs[strings.ToLower(author.Login)]
+	}
+
+	if id != 0 && author.ID != id {
+		return fmt.Sprintf("author %s has the ID %d instead of %d", author.Login, author.ID, id)
+	}
+
+	return ""
+}
+
+// verifyCommits returns the first commit of the pull request without a verified signature, or an empty string
+// if all of them are verified.
+func (n *Bundler) verifyCommits(number int) (string, error) {
+	commits, _, err := n.Pulls.ListCommits(context.Background(), n.Owner, n.Repo, number, &github.ListOptions{
+		PerPage: defaultNumberOfItemsPerPage,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list commits of pull request #%d: %w", number, err)
+	}
+
+	for _, commit := range commits {
+		if !commit.GetCommit().GetVerification().GetVerified() {
+			return fmt.Sprintf("commit %s is not signed with a verified signature", commit.GetSHA()), nil
+		}
+	}
+
+	return "", nil
+}
+
+// trusted returns whether the change request passes the verification. Change requests which don't, or which
+// can't be verified, are logged and skipped.
+func (n *Bundler) trusted(request *forge.ChangeRequest) bool {
+	reason, err := n.verify(request)
+	if err != nil {
+		n.Logger.Log("skipping PR #%d, failed to verify it: %s\n", request.Number, err)
+
+		return false
+	}
+
+	if reason != "" {
+		n.Logger.Log("skipping PR #%d: %s\n", request.Number, reason)
+
+		return false
+	}
+
+	return true
+}
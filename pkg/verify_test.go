@@ -0,0 +1,180 @@
+package pkg_test
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v43/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Skarlso/dependabot-bundler/pkg"
+	forgeFakes "github.com/Skarlso/dependabot-bundler/pkg/forge/fakes"
+)
+
+// dependabotPullRequest returns a pull request opened by Dependabot from a branch of test/test.
+func dependabotPullRequest() *github.PullRequest {
+	return &github.PullRequest{
+		Title: github.String("Bump github.com/test/test from 1.0.0 to 1.1.0"),
+		User: &github.User{
+			Login: github.String("dependabot[bot]"),
+			ID:    github.Int64(49699333),
+			Type:  github.String("Bot"),
+		},
+		Head: &github.PullRequestBranch{
+			Ref:  github.String("dependabot/go_modules/github.com/test/test-1.1.0"),
+			Repo: &github.Repository{FullName: github.String("test/test")},
+		},
+		Base: &github.PullRequestBranch{
+			Ref:  github.String("main"),
+			Repo: &github.Repository{FullName: github.String("test/test")},
+		},
+	}
+}
+
+func TestBundlerVerifyBots(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.VerifyBots = true
+	f.pulls.GetReturns(dependabotPullRequest(), nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+	assert.Equal(t, 1, f.updater.UpdateCallCount())
+	assert.Equal(t, 1, f.pulls.CreateCallCount())
+	// the commits are only listed when they have to be verified
+	assert.Equal(t, 0, f.pulls.ListCommitsCallCount())
+}
+
+func TestBundlerVerifyBotsSkipsUntrusted(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(pr *github.PullRequest)
+		bots   []pkg.Bot
+	}{
+		{
+			name: "a user with the branch of the bot",
+			modify: func(pr *github.PullRequest) {
+				pr.User = &github.User{Login: github.String("someone"), ID: github.Int64(1), Type: github.String("User")}
+			},
+		},
+		{
+			name: "a user account with the login of the bot",
+			modify: func(pr *github.PullRequest) {
+				pr.User.Type = github.String("User")
+			},
+		},
+		{
+			name: "a bot with the wrong ID",
+			modify: func(pr *github.PullRequest) {
+				pr.User.ID = github.Int64(1)
+			},
+		},
+		{
+			name: "a bot with another ID than the configured one",
+			bots: []pkg.Bot{{Name: "app/dependabot", ID: 1}},
+		},
+		{
+			name: "a branch of a fork",
+			modify: func(pr *github.PullRequest) {
+				pr.Head.Repo.FullName = github.String("someone/test")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundler, f := newTestBundler()
+			bundler.VerifyBots = true
+			bundler.Bots = tt.bots
+
+			pr := dependabotPullRequest()
+			if tt.modify != nil {
+				tt.modify(pr)
+			}
+
+			f.pulls.GetReturns(pr, nil, nil)
+
+			require.NoError(t, bundler.Bundle())
+			assert.Equal(t, 0, f.updater.UpdateCallCount())
+			assert.Equal(t, 0, f.pulls.CreateCallCount())
+		})
+	}
+}
+
+func TestBundlerRequireVerifiedCommits(t *testing.T) {
+	bundler, f := newTestBundler()
+	bundler.RequireVerifiedCommits = true
+	f.pulls.ListCommitsReturns([]*github.RepositoryCommit{
+		{
+			SHA:    github.String("aaa"),
+			Commit: &github.Commit{Verification: &github.SignatureVerification{Verified: github.Bool(true)}},
+		},
+		{
+			SHA:    github.String("bbb"),
+			Commit: &github.Commit{Verification: &github.SignatureVerification{Verified: github.Bool(false)}},
+		},
+	}, nil, nil)
+
+	require.NoError(t, bundler.Bundle())
+	require.Equal(t, 1, f.pulls.ListCommitsCallCount())
+	_, owner, repo, number, _ := f.pulls.ListCommitsArgsForCall(0)
+	assert.Equal(t, "owner", owner)
+	assert.Equal(t, "repo", repo)
+	assert.Equal(t, 1, number)
+	assert.Equal(t, 0, f.updater.UpdateCallCount())
+}
+
+func TestBundlerVerifyBotsOnForge(t *testing.T) {
+	bundler, f := newTestBundler()
+	fakeForge := &forgeFakes.FakeForge{}
+	bundler.Forge = fakeForge
+	bundler.VerifyBots = true
+	bundler.RequireVerifiedCommits = true
+
+	assert.ErrorContains(t, bundler.Bundle(), "requiring verified commits is only supported on GitHub")
+	assert.Equal(t, 0, fakeForge.ListChangeRequestsCallCount())
+	assert.Equal(t, 0, f.pulls.ListCommitsCallCount())
+}
+
+func TestBundlerVerifyBotsOnEnterprise(t *testing.T) {
+	tests := []struct {
+		name    string
+		bots    []pkg.Bot
+		bundled bool
+	}{
+		{
+			name:    "the ID of github.com is not checked",
+			bundled: true,
+		},
+		{
+			name:    "a configured ID is checked",
+			bots:    []pkg.Bot{{Name: "app/dependabot", ID: 1}},
+			bundled: false,
+		},
+		{
+			name:    "a bot with the configured ID",
+			bots:    []pkg.Bot{{Name: "app/dependabot", ID: 42}},
+			bundled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundler, f := newTestBundler()
+			bundler.VerifyBots = true
+			bundler.Enterprise = true
+			bundler.Bots = tt.bots
+
+			pr := dependabotPullRequest()
+			// the bot account of the app has another ID on a GitHub Enterprise Server
+			pr.User.ID = github.Int64(42)
+			f.pulls.GetReturns(pr, nil, nil)
+
+			require.NoError(t, bundler.Bundle())
+
+			if tt.bundled {
+				assert.Equal(t, 1, f.pulls.CreateCallCount())
+			} else {
+				assert.Equal(t, 0, f.pulls.CreateCallCount())
+			}
+		})
+	}
+}
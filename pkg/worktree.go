@@ -0,0 +1,84 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+)
+
+// createWorktree creates a temporary git worktree from the target branch and changes the current working
+// directory into it. That way the updaters, which work in the current working directory, never touch the
+// checkout of the user. The returned function switches back and removes the worktree unless keep is set.
+// It has to be called even if the run fails.
+func (n *Bundler) createWorktree() (func(keep bool), error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current working folder: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "dependabot-bundler-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary folder: %w", err)
+	}
+
+	removeDir := func() {
+		if err := os.RemoveAll(dir); err != nil {
+			n.Logger.Log("failed to remove temporary folder %s: %s\n", dir, err)
+		}
+	}
+
+	if err := n.runGit(cwd, "fetch", defaultRemote, n.TargetBranch); err != nil {
+		removeDir()
+
+		return nil, err
+	}
+
+	if err := n.runGit(cwd, "worktree", "add", "--detach", dir, "FETCH_HEAD"); err != nil {
+		removeDir()
+
+		return nil, err
+	}
+
+	removeWorktree := func() {
+		if err := n.runGit(cwd, "worktree", "remove", "--force", dir); err != nil {
+			n.Logger.Log("failed to remove worktree %s: %s\n", dir, err)
+		}
+
+		removeDir()
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		removeWorktree()
+
+		return nil, fmt.Errorf("failed to change into worktree: %w", err)
+	}
+
+	n.Logger.Debug("applying updates in worktree %s\n", dir)
+
+	return func(keep bool) {
+		if err := os.Chdir(cwd); err != nil {
+			n.Logger.Log("failed to change back to %s: %s\n", cwd, err)
+		}
+
+		if keep {
+			n.Logger.Log("keeping worktree at %s\n", dir)
+
+			return
+		}
+
+		removeWorktree()
+	}, nil
+}
+
+// runGit runs a git command in the given folder. The repository is trusted even if it's owned by another
+// user, which is the case in the container of the action, where the workspace belongs to the runner.
+func (n *Bundler) runGit(workdir string, args ...string) error {
+	trusted := append([]string{"-c", "safe.directory=*"}, args...)
+
+	if output, err := n.Runner.Run("git", workdir, trusted...); err != nil {
+		n.Logger.Debug("git %s failed, output from command: %s\n", args[0], string(output))
+
+		return fmt.Errorf("failed to run git %s: %w", args[0], err)
+	}
+
+	return nil
+}